@@ -87,14 +87,15 @@ func (b *Backend) Build(ctx context.Context, config backend.BuildConfig) (string
 	return imageID, err
 }
 
-// PruneCache removes all cached build sources
-func (b *Backend) PruneCache(ctx context.Context) (*types.BuildCachePruneReport, error) {
+// PruneCache removes all cached build sources. If dryRun is true, nothing is
+// removed, and the report of what would be removed is returned.
+func (b *Backend) PruneCache(ctx context.Context, dryRun bool) (*types.BuildCachePruneReport, error) {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	var fsCacheSize uint64
 	eg.Go(func() error {
 		var err error
-		fsCacheSize, err = b.fsCache.Prune(ctx)
+		fsCacheSize, err = b.fsCache.Prune(ctx, dryRun)
 		if err != nil {
 			return errors.Wrap(err, "failed to prune fscache")
 		}
@@ -104,7 +105,7 @@ func (b *Backend) PruneCache(ctx context.Context) (*types.BuildCachePruneReport,
 	var buildCacheSize int64
 	eg.Go(func() error {
 		var err error
-		buildCacheSize, err = b.buildkit.Prune(ctx)
+		buildCacheSize, err = b.buildkit.Prune(ctx, dryRun)
 		if err != nil {
 			return errors.Wrap(err, "failed to prune build cache")
 		}
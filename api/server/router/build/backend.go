@@ -13,8 +13,8 @@ type Backend interface {
 	// TODO: make this return a reference instead of string
 	Build(context.Context, backend.BuildConfig) (string, error)
 
-	// Prune build cache
-	PruneCache(context.Context) (*types.BuildCachePruneReport, error)
+	// Prune build cache, or only report what would be pruned if dryRun is true
+	PruneCache(ctx context.Context, dryRun bool) (*types.BuildCachePruneReport, error)
 
 	Cancel(context.Context, string) error
 }
@@ -161,7 +161,7 @@ func parseVersion(s string) (types.BuilderVersion, error) {
 }
 
 func (br *buildRouter) postPrune(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-	report, err := br.backend.PruneCache(ctx)
+	report, err := br.backend.PruneCache(ctx, httputils.BoolValue(r, "dry-run"))
 	if err != nil {
 		return err
 	}
@@ -115,6 +115,33 @@ func (s *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	return s.backend.ContainerStats(ctx, vars["name"], config)
 }
 
+func (s *containerRouter) getAllContainersStats(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	filter, err := filters.FromJSON(r.Form.Get("filters"))
+	if err != nil {
+		return err
+	}
+
+	stream := httputils.BoolValueOrDefault(r, "stream", true)
+	if !stream {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	config := &backend.ContainersStatsConfig{
+		ContainerStatsConfig: backend.ContainerStatsConfig{
+			Stream:    stream,
+			OutStream: w,
+			Version:   httputils.VersionFromContext(ctx),
+		},
+		Filters: filter,
+	}
+
+	return s.backend.ContainersStats(ctx, config)
+}
+
 func (s *containerRouter) getContainersLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -141,8 +168,25 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 		ShowStderr: stderr,
 		Details:    httputils.BoolValue(r, "details"),
 	}
+	if !versions.LessThan(httputils.VersionFromContext(ctx), "1.39") {
+		logFilters, err := filters.FromJSON(r.Form.Get("filters"))
+		if err != nil {
+			return err
+		}
+		logsConfig.Grep = r.Form.Get("grep")
+		logsConfig.Filters = logFilters
+	}
+
+	backendConfig := logsConfig
+	if versions.LessThan(httputils.VersionFromContext(ctx), "1.39") {
+		// Before API 1.39, the tail counts the messages of both streams, and
+		// the streams are selected when the messages are written.
+		c := *logsConfig
+		c.ShowStdout, c.ShowStderr = true, true
+		backendConfig = &c
+	}
 
-	msgs, tty, err := s.backend.ContainerLogs(ctx, containerName, logsConfig)
+	msgs, tty, err := s.backend.ContainerLogs(ctx, containerName, backendConfig)
 	if err != nil {
 		return err
 	}
@@ -653,7 +697,7 @@ func (s *containerRouter) postContainersPrune(ctx context.Context, w http.Respon
 		return errdefs.InvalidParameter(err)
 	}
 
-	pruneReport, err := s.backend.ContainersPrune(ctx, pruneFilters)
+	pruneReport, err := s.backend.ContainersPrune(ctx, pruneFilters, httputils.BoolValue(r, "dry-run"))
 	if err != nil {
 		return err
 	}
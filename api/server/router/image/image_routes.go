@@ -129,12 +129,19 @@ func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter,
 	image := vars["name"]
 	tag := r.Form.Get("tag")
 
+	compression := r.Form.Get("compression")
+	switch compression {
+	case "", "gzip", "zstd":
+	default:
+		return errdefs.InvalidParameter(errors.Errorf("invalid layer compression %q: must be \"gzip\" or \"zstd\"", compression))
+	}
+
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
 
 	w.Header().Set("Content-Type", "application/json")
 
-	if err := s.backend.PushImage(ctx, image, tag, metaHeaders, authConfig, output); err != nil {
+	if err := s.backend.PushImage(ctx, image, tag, compression, metaHeaders, authConfig, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -148,6 +155,24 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		return err
 	}
 
+	format := r.Form.Get("format")
+	switch format {
+	case "", "docker", "oci":
+	default:
+		return errdefs.InvalidParameter(errors.Errorf("invalid image format %q: must be \"docker\" or \"oci\"", format))
+	}
+
+	compression := r.Form.Get("compression")
+	switch compression {
+	case "", "none":
+	case "gzip", "zstd":
+		if format != "oci" {
+			return errdefs.InvalidParameter(errors.New("layer compression is only supported by the \"oci\" format"))
+		}
+	default:
+		return errdefs.InvalidParameter(errors.Errorf("invalid layer compression %q: must be \"none\", \"gzip\" or \"zstd\"", compression))
+	}
+
 	w.Header().Set("Content-Type", "application/x-tar")
 
 	output := ioutils.NewWriteFlusher(w)
@@ -159,7 +184,7 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		names = r.Form["names"]
 	}
 
-	if err := s.backend.ExportImage(names, output); err != nil {
+	if err := s.backend.ExportImage(names, format, compression, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -316,7 +341,7 @@ func (s *imageRouter) postImagesPrune(ctx context.Context, w http.ResponseWriter
 		return err
 	}
 
-	pruneReport, err := s.backend.ImagesPrune(ctx, pruneFilters)
+	pruneReport, err := s.backend.ImagesPrune(ctx, pruneFilters, httputils.BoolValue(r, "dry-run"))
 	if err != nil {
 		return err
 	}
@@ -320,7 +320,7 @@ func (n *networkRouter) postNetworksPrune(ctx context.Context, w http.ResponseWr
 		return err
 	}
 
-	pruneReport, err := n.backend.NetworksPrune(ctx, pruneFilters)
+	pruneReport, err := n.backend.NetworksPrune(ctx, pruneFilters, httputils.BoolValue(r, "dry-run"))
 	if err != nil {
 		return err
 	}
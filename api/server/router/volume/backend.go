@@ -2,11 +2,13 @@ package volume // import "github.com/docker/docker/api/server/router/volume"
 
 import (
 	"context"
+	"io"
 
 	"github.com/docker/docker/volume/service/opts"
 	// TODO return types need to be refactored into pkg
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/archive"
 )
 
 // Backend is the methods that need to be implemented to provide
@@ -16,5 +18,8 @@ type Backend interface {
 	Get(ctx context.Context, name string, opts ...opts.GetOption) (*types.Volume, error)
 	Create(ctx context.Context, name, driverName string, opts ...opts.CreateOption) (*types.Volume, error)
 	Remove(ctx context.Context, name string, opts ...opts.RemoveOption) error
-	Prune(ctx context.Context, pruneFilters filters.Args) (*types.VolumesPruneReport, error)
+	Prune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (*types.VolumesPruneReport, error)
+	Export(ctx context.Context, name string, compression archive.Compression) (io.ReadCloser, error)
+	Import(ctx context.Context, name string, r io.Reader) error
+	Clone(ctx context.Context, name, newName string, labels map[string]string) (*types.Volume, error)
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/volume/service/opts"
 	"github.com/pkg/errors"
 )
@@ -35,7 +37,7 @@ func (v *volumeRouter) getVolumeByName(ctx context.Context, w http.ResponseWrite
 		return err
 	}
 
-	volume, err := v.backend.Get(ctx, vars["name"], opts.WithGetResolveStatus)
+	volume, err := v.backend.Get(ctx, vars["name"], opts.WithGetResolveStatus, opts.WithGetResolveReferences)
 	if err != nil {
 		return err
 	}
@@ -88,9 +90,78 @@ func (v *volumeRouter) postVolumesPrune(ctx context.Context, w http.ResponseWrit
 		return err
 	}
 
-	pruneReport, err := v.backend.Prune(ctx, pruneFilters)
+	pruneReport, err := v.backend.Prune(ctx, pruneFilters, httputils.BoolValue(r, "dry-run"))
 	if err != nil {
 		return err
 	}
 	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
 }
+
+func (v *volumeRouter) getVolumeExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var (
+		compression archive.Compression
+		contentType string
+	)
+	switch c := r.Form.Get("compression"); c {
+	case "", "none":
+		compression, contentType = archive.Uncompressed, "application/x-tar"
+	case "gzip":
+		compression, contentType = archive.Gzip, "application/gzip"
+	case "zstd":
+		compression, contentType = archive.Zstd, "application/zstd"
+	default:
+		return errdefs.InvalidParameter(errors.Errorf("invalid compression %q: must be \"none\", \"gzip\" or \"zstd\"", c))
+	}
+
+	rdr, err := v.backend.Export(ctx, vars["name"], compression)
+	if err != nil {
+		return err
+	}
+	defer rdr.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	output := ioutils.NewWriteFlusher(w)
+	defer output.Close()
+	_, err = io.Copy(output, rdr)
+	return err
+}
+
+func (v *volumeRouter) postVolumeImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := v.backend.Import(ctx, vars["name"], r.Body); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (v *volumeRouter) postVolumeClone(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := httputils.CheckForJSON(r); err != nil {
+		return err
+	}
+
+	var req volumetypes.VolumeCloneBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err == io.EOF {
+			return errdefs.InvalidParameter(errors.New("got EOF while reading request body"))
+		}
+		return err
+	}
+
+	volume, err := v.backend.Clone(ctx, vars["name"], req.Name, req.Labels)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, volume)
+}
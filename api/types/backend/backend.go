@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 )
 
 // ContainerAttachConfig holds the streams to use when connecting to a container to view logs.
@@ -77,6 +78,15 @@ type ContainerStatsConfig struct {
 	Version   string
 }
 
+// ContainersStatsConfig holds information for configuring the runtime
+// behavior of a backend.ContainersStats() call.
+type ContainersStatsConfig struct {
+	ContainerStatsConfig
+	// Filters selects the running containers to return the stats of,
+	// using the same filters as the container list.
+	Filters filters.Args
+}
+
 // ExecInspect holds information about a running process started
 // with docker exec.
 type ExecInspect struct {
@@ -91,6 +101,7 @@ type ExecInspect struct {
 	ContainerID   string
 	DetachKeys    []byte
 	Pid           int
+	Timeout       time.Duration `json:",omitempty"`
 }
 
 // ExecProcessConfig holds information about the exec process
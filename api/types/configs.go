@@ -1,6 +1,8 @@
 package types // import "github.com/docker/docker/api/types"
 
 import (
+	"time"
+
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 )
@@ -39,6 +41,10 @@ type ExecConfig struct {
 	Env          []string // Environment variables
 	WorkingDir   string   // Working directory
 	Cmd          []string // Execution commands and args
+
+	// Timeout is the maximum time the process may run. Once it is
+	// exceeded, the process is terminated. Zero means no timeout.
+	Timeout time.Duration `json:",omitempty"`
 }
 
 // PluginRmConfig holds arguments for plugin remove.
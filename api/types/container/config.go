@@ -22,6 +22,13 @@ type HealthConfig struct {
 	// {"NONE"} : disable healthcheck
 	// {"CMD", args...} : exec arguments directly
 	// {"CMD-SHELL", command} : run command with system's default shell
+	// {"HTTP", url, options...} : HTTP GET url from the container's network namespace.
+	//     Options are "status=<code>[-<code>]" (default 200-399), "body=<regexp>" and
+	//     "insecure=true" to skip the verification of the server certificate
+	// {"TCP", "[host]:port"} : open a TCP connection from the container's network namespace
+	// The host of HTTP and TCP probes must be an IP address or "localhost".
+	// HTTP and TCP probes are only supported for Linux containers, and cannot
+	// be set from a Dockerfile HEALTHCHECK instruction.
 	Test []string `json:",omitempty"`
 
 	// Zero means to inherit. Durations are expressed as integer nanoseconds.
@@ -2,6 +2,7 @@ package container // import "github.com/docker/docker/api/types/container"
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/mount"
@@ -177,6 +178,29 @@ func (c CgroupSpec) Container() string {
 	return ""
 }
 
+// CgroupnsMode represents the cgroup namespace mode of the container.
+type CgroupnsMode string
+
+// IsPrivate indicates whether the container uses its own private cgroup namespace.
+func (c CgroupnsMode) IsPrivate() bool {
+	return c == "private"
+}
+
+// IsHost indicates whether the container shares the host's cgroup namespace.
+func (c CgroupnsMode) IsHost() bool {
+	return c == "host"
+}
+
+// IsEmpty indicates whether the container cgroup namespace mode is unset.
+func (c CgroupnsMode) IsEmpty() bool {
+	return c == ""
+}
+
+// Valid indicates whether the cgroup namespace mode is valid.
+func (c CgroupnsMode) Valid() bool {
+	return c.IsEmpty() || c.IsPrivate() || c.IsHost()
+}
+
 // UTSMode represents the UTS namespace of the container.
 type UTSMode string
 
@@ -255,6 +279,27 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              string
 	MaximumRetryCount int
+	// Backoff configures the delay between restarts. A nil value uses the
+	// daemon's default backoff.
+	Backoff *RestartBackoff `json:",omitempty"`
+}
+
+// RestartBackoff configures the delay applied before a container is
+// restarted. The delay starts at InitialDelay and is multiplied by
+// Multiplier after every restart, up to MaxDelay. Zero values use the
+// daemon's defaults. Durations are expressed as integer nanoseconds.
+type RestartBackoff struct {
+	InitialDelay time.Duration `json:",omitempty"` // InitialDelay is the delay before the first restart.
+	MaxDelay     time.Duration `json:",omitempty"` // MaxDelay is the upper bound of the delay between restarts.
+	// Multiplier is the factor the delay grows by after each restart. It
+	// must be 1 or greater.
+	Multiplier float64 `json:",omitempty"`
+	// Jitter is the fraction (between 0 and 1) of the delay that is
+	// randomly added or removed from each restart delay.
+	Jitter float64 `json:",omitempty"`
+	// ResetWindow is the time a container has to run for the delay to be
+	// reset to InitialDelay.
+	ResetWindow time.Duration `json:",omitempty"`
 }
 
 // IsNone indicates whether the container has the "no" restart policy.
@@ -284,7 +329,27 @@ func (rp *RestartPolicy) IsUnlessStopped() bool {
 
 // IsSame compares two RestartPolicy to see if they are the same
 func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
-	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
+	if rp.Name != tp.Name || rp.MaximumRetryCount != tp.MaximumRetryCount {
+		return false
+	}
+	if rp.Backoff == nil || tp.Backoff == nil {
+		return rp.Backoff == tp.Backoff
+	}
+	return *rp.Backoff == *tp.Backoff
+}
+
+// UnhealthyPolicy represents the action taken by the daemon when the
+// container becomes unhealthy.
+type UnhealthyPolicy struct {
+	// Action is one of "none", "restart", "stop", "kill" or "exec".
+	// "restart" stops the container and lets the restart manager start it
+	// again, applying the restart backoff.
+	Action string
+	// Signal is the signal sent to the container by the "kill" action.
+	// Defaults to SIGKILL.
+	Signal string `json:",omitempty"`
+	// Cmd is the command run in the container by the "exec" action.
+	Cmd strslice.StrSlice `json:",omitempty"`
 }
 
 // LogMode is a type to define the available modes for logging
@@ -356,15 +421,16 @@ type UpdateConfig struct {
 // Portable information *should* appear in Config.
 type HostConfig struct {
 	// Applicable to all platforms
-	Binds           []string      // List of volume bindings for this container
-	ContainerIDFile string        // File (path) where the containerId is written
-	LogConfig       LogConfig     // Configuration of the logs for this container
-	NetworkMode     NetworkMode   // Network mode to use for the container
-	PortBindings    nat.PortMap   // Port mapping between the exposed port (container) and the host
-	RestartPolicy   RestartPolicy // Restart policy to be used for the container
-	AutoRemove      bool          // Automatically remove container when it exits
-	VolumeDriver    string        // Name of the volume driver used to mount volumes
-	VolumesFrom     []string      // List of volumes to take from other container
+	Binds           []string         // List of volume bindings for this container
+	ContainerIDFile string           // File (path) where the containerId is written
+	LogConfig       LogConfig        // Configuration of the logs for this container
+	NetworkMode     NetworkMode      // Network mode to use for the container
+	PortBindings    nat.PortMap      // Port mapping between the exposed port (container) and the host
+	RestartPolicy   RestartPolicy    // Restart policy to be used for the container
+	UnhealthyPolicy *UnhealthyPolicy `json:",omitempty"` // Action to take when the container becomes unhealthy
+	AutoRemove      bool             // Automatically remove container when it exits
+	VolumeDriver    string           // Name of the volume driver used to mount volumes
+	VolumesFrom     []string         // List of volumes to take from other container
 
 	// Applicable to UNIX platforms
 	CapAdd          strslice.StrSlice // List of kernel capabilities to add to the container
@@ -376,6 +442,7 @@ type HostConfig struct {
 	GroupAdd        []string          // List of additional groups that the container process will run as
 	IpcMode         IpcMode           // IPC namespace to use for the container
 	Cgroup          CgroupSpec        // Cgroup to use for the container
+	CgroupnsMode    CgroupnsMode      `json:",omitempty"` // Cgroup namespace mode to use for the container
 	Links           []string          // List of links (in the name:alias form)
 	OomScoreAdj     int               // Container preference for OOM-killing
 	PidMode         PidMode           // PID namespace to use for the container
@@ -23,6 +23,9 @@ type ImageSummary struct {
 	// Required: true
 	Labels map[string]string `json:"Labels"`
 
+	// Date and time at which the image was last used to create a container or by a build, as a Unix timestamp. Zero if the image was never used.
+	LastUsed int64 `json:"LastUsed,omitempty"`
+
 	// parent Id
 	// Required: true
 	ParentID string `json:"ParentId"`
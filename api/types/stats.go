@@ -15,6 +15,28 @@ type ThrottlingData struct {
 	ThrottledTime uint64 `json:"throttled_time"`
 }
 
+// PressureData stores the stall averages of a resource, as percentages of
+// time, and the total stall time. Linux only.
+type PressureData struct {
+	// Share of time stalled over the last 10 seconds.
+	Avg10 float64 `json:"avg10"`
+	// Share of time stalled over the last 60 seconds.
+	Avg60 float64 `json:"avg60"`
+	// Share of time stalled over the last 300 seconds.
+	Avg300 float64 `json:"avg300"`
+	// Total stall time in microseconds.
+	Total uint64 `json:"total"`
+}
+
+// PressureStats stores the pressure stall information (PSI) of a resource.
+// Linux only, and only populated when the kernel provides it for cgroups.
+type PressureStats struct {
+	// Time in which at least some tasks were stalled on the resource.
+	Some *PressureData `json:"some,omitempty"`
+	// Time in which all non-idle tasks were stalled on the resource.
+	Full *PressureData `json:"full,omitempty"`
+}
+
 // CPUUsage stores All CPU stats aggregated since container inception.
 type CPUUsage struct {
 	// Total CPU time consumed.
@@ -52,6 +74,9 @@ type CPUStats struct {
 
 	// Throttling Data. Linux only.
 	ThrottlingData ThrottlingData `json:"throttling_data,omitempty"`
+
+	// CPU pressure stall information. Linux only.
+	Pressure *PressureStats `json:"pressure,omitempty"`
 }
 
 // MemoryStats aggregates all memory stats since container inception on Linux.
@@ -69,6 +94,10 @@ type MemoryStats struct {
 	// number of times memory usage hits limits.
 	Failcnt uint64 `json:"failcnt,omitempty"`
 	Limit   uint64 `json:"limit,omitempty"`
+	// number of processes killed by the OOM killer.
+	OOMKills uint64 `json:"oom_kills,omitempty"`
+	// memory pressure stall information.
+	Pressure *PressureStats `json:"pressure,omitempty"`
 
 	// Windows Memory Stats
 	// See https://technet.microsoft.com/en-us/magazine/ff382715.aspx
@@ -104,6 +133,9 @@ type BlkioStats struct {
 	IoMergedRecursive       []BlkioStatEntry `json:"io_merged_recursive"`
 	IoTimeRecursive         []BlkioStatEntry `json:"io_time_recursive"`
 	SectorsRecursive        []BlkioStatEntry `json:"sectors_recursive"`
+
+	// IO pressure stall information.
+	Pressure *PressureStats `json:"pressure,omitempty"`
 }
 
 // StorageStats is the disk I/O stats for read/write on Windows.
@@ -10,6 +10,11 @@ type Service struct {
 	PreviousSpec *ServiceSpec  `json:",omitempty"`
 	Endpoint     Endpoint      `json:",omitempty"`
 	UpdateStatus *UpdateStatus `json:",omitempty"`
+
+	// ServiceStatus is an optional, extra field indicating the number of
+	// tasks of the service. It is only set when the services are listed with
+	// the Status option.
+	ServiceStatus *ServiceStatus `json:",omitempty"`
 }
 
 // ServiceSpec represents the spec of a service.
@@ -70,6 +75,18 @@ type ReplicatedService struct {
 // GlobalService is a kind of ServiceMode.
 type GlobalService struct{}
 
+// ServiceStatus represents the number of tasks of a service.
+type ServiceStatus struct {
+	// RunningTasks is the number of tasks of the service which are
+	// running.
+	RunningTasks uint64
+
+	// DesiredTasks is the number of tasks of the service which should be
+	// running: the number of replicas of replicated services, and the
+	// number of nodes the tasks of global services are scheduled on.
+	DesiredTasks uint64
+}
+
 const (
 	// UpdateFailureActionPause PAUSE
 	UpdateFailureActionPause = "pause"
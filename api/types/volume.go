@@ -30,6 +30,12 @@ type Volume struct {
 	// Required: true
 	Options map[string]string `json:"Options"`
 
+	// The mounts of the volume by the containers referencing it. This
+	// information is returned by the `GET /volumes` and
+	// `GET /volumes/{name}` endpoints, and omitted in other endpoints.
+	//
+	References []VolumeReference `json:"References,omitempty"`
+
 	// The level at which the volume exists. Either `global` for cluster-wide, or `local` for machine level.
 	// Required: true
 	Scope string `json:"Scope"`
@@ -0,0 +1,21 @@
+package types
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+// VolumeReference A mount of a volume by a container
+// swagger:model VolumeReference
+type VolumeReference struct {
+
+	// ID of the container.
+	// Required: true
+	ContainerID string `json:"ContainerID"`
+
+	// Name of the container.
+	// Required: true
+	ContainerName string `json:"ContainerName"`
+
+	// Path of the mount in the container.
+	// Required: true
+	Destination string `json:"Destination"`
+}
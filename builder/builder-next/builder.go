@@ -16,6 +16,7 @@ import (
 	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/pkg/system"
 	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/control"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/session"
@@ -92,8 +93,17 @@ func (b *Builder) DiskUsage(ctx context.Context) ([]*types.BuildCache, error) {
 	return items, nil
 }
 
-// Prune clears all reclaimable build cache
-func (b *Builder) Prune(ctx context.Context) (int64, error) {
+// Prune clears all reclaimable build cache. If dryRun is true, nothing is
+// cleared, and the size of the build cache which would be cleared is returned.
+func (b *Builder) Prune(ctx context.Context, dryRun bool) (int64, error) {
+	if dryRun {
+		duResp, err := b.controller.DiskUsage(ctx, &controlapi.DiskUsageRequest{})
+		if err != nil {
+			return 0, err
+		}
+		return prunableSize(duResp.Record), nil
+	}
+
 	ch := make(chan *controlapi.UsageRecord)
 
 	eg, ctx := errgroup.WithContext(ctx)
@@ -121,6 +131,37 @@ func (b *Builder) Prune(ctx context.Context) (int64, error) {
 	return size, nil
 }
 
+// prunableSize returns the size of the records which a prune of the cache
+// manager removes. Like the prune, it skips the records in use, the internal
+// and frontend records, and the records shared with the images, and keeps the
+// parents of the records it skips.
+func prunableSize(records []*controlapi.UsageRecord) int64 {
+	parents := make(map[string]string, len(records))
+	kept := make(map[string]bool)
+	for _, r := range records {
+		parents[r.ID] = r.Parent
+		switch {
+		case r.InUse, r.Shared,
+			r.RecordType == string(client.UsageRecordTypeInternal),
+			r.RecordType == string(client.UsageRecordTypeFrontend):
+			kept[r.ID] = true
+		}
+	}
+	for id := range kept {
+		for p := parents[id]; p != "" && !kept[p]; p = parents[p] {
+			kept[p] = true
+		}
+	}
+
+	var size int64
+	for _, r := range records {
+		if !kept[r.ID] {
+			size += r.Size_
+		}
+	}
+	return size
+}
+
 // Build executes a build request
 func (b *Builder) Build(ctx context.Context, opt backend.BuildConfig) (*builder.Result, error) {
 	var rc = opt.Source
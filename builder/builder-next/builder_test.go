@@ -0,0 +1,23 @@
+package buildkit
+
+import (
+	"testing"
+
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"gotest.tools/assert"
+)
+
+func TestPrunableSize(t *testing.T) {
+	records := []*controlapi.UsageRecord{
+		{ID: "base", Size_: 1},
+		{ID: "child", Parent: "base", Size_: 2},
+		{ID: "in-use", Parent: "base", Size_: 4, InUse: true},
+		{ID: "root", Size_: 8},
+		{ID: "shared", Parent: "root", Size_: 16, Shared: true},
+		{ID: "frontend", Size_: 32, RecordType: "frontend"},
+		{ID: "internal-parent", Size_: 64},
+		{ID: "internal", Parent: "internal-parent", Size_: 128, RecordType: "internal"},
+		{ID: "unused", Size_: 256, RecordType: "regular"},
+	}
+	assert.Equal(t, prunableSize(records), int64(2+256))
+}
@@ -242,6 +242,11 @@ func buildLabelOptions(labels map[string]string, stages []instructions.Stage) {
 func (b *Builder) build(source builder.Source, dockerfile *parser.Result) (*builder.Result, error) {
 	defer b.imageSources.Unmount()
 
+	for _, n := range dockerfile.AST.Children {
+		if err := checkHealthcheckProbe(n); err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+	}
 	stages, metaArgs, err := instructions.Parse(dockerfile.AST)
 	if err != nil {
 		if instructions.IsUnknownInstruction(err) {
@@ -405,6 +410,9 @@ func BuildFromConfig(config *container.Config, changes []string, os string) (*co
 
 	var commands []instructions.Command
 	for _, n := range dockerfile.AST.Children {
+		if err := checkHealthcheckProbe(n); err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
 		cmd, err := instructions.ParseCommand(n)
 		if err != nil {
 			return nil, errdefs.InvalidParameter(err)
@@ -25,6 +25,7 @@ import (
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/go-connections/nat"
+	"github.com/moby/buildkit/frontend/dockerfile/command"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
@@ -202,6 +203,9 @@ func dispatchTriggeredOnBuild(d dispatchRequest, triggers []string) error {
 		if len(ast.AST.Children) != 1 {
 			return errors.New("onbuild trigger should be a single expression")
 		}
+		if err := checkHealthcheckProbe(ast.AST.Children[0]); err != nil {
+			return err
+		}
 		cmd, err := instructions.ParseCommand(ast.AST.Children[0])
 		if err != nil {
 			if instructions.IsUnknownInstruction(err) {
@@ -467,6 +471,27 @@ func dispatchHealthcheck(d dispatchRequest, c *instructions.HealthCheckCommand)
 	return d.builder.commit(d.state, fmt.Sprintf("HEALTHCHECK %q", runConfig.Healthcheck))
 }
 
+// checkHealthcheckProbe rejects HEALTHCHECK HTTP and HEALTHCHECK TCP, including
+// as an ONBUILD trigger. These probes can only be set in the healthcheck of the
+// container configuration: the Dockerfile parser only knows CMD and NONE and
+// would fail with a misleading error otherwise.
+func checkHealthcheckProbe(node *parser.Node) error {
+	switch node.Value {
+	case command.Onbuild:
+		if node.Next != nil && len(node.Next.Children) > 0 {
+			return checkHealthcheckProbe(node.Next.Children[0])
+		}
+	case command.Healthcheck:
+		if node.Next == nil {
+			return nil
+		}
+		if typ := strings.ToUpper(node.Next.Value); typ == "HTTP" || typ == "TCP" {
+			return errors.Errorf("HEALTHCHECK %s is not supported in a Dockerfile, set %s healthchecks in the container configuration instead", typ, typ)
+		}
+	}
+	return nil
+}
+
 // ENTRYPOINT /usr/sbin/nginx
 //
 // Set the entrypoint to /usr/sbin/nginx. Will accept the CMD as the arguments
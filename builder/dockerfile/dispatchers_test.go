@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/docker/docker/api/types"
@@ -15,6 +16,7 @@ import (
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/go-connections/nat"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
@@ -297,6 +299,30 @@ func TestHealthcheckCmd(t *testing.T) {
 	assert.Check(t, is.DeepEqual(expectedTest, sb.state.runConfig.Healthcheck.Test))
 }
 
+func TestHealthcheckProbeRejected(t *testing.T) {
+	for _, line := range []string{
+		"HEALTHCHECK HTTP http://localhost:8080/health",
+		"HEALTHCHECK --interval=5s tcp localhost:5432",
+		`HEALTHCHECK HTTP ["http://localhost:8080/health", "status=200"]`,
+		"ONBUILD HEALTHCHECK TCP localhost:5432",
+	} {
+		result, err := parser.Parse(strings.NewReader(line))
+		assert.NilError(t, err)
+		err = checkHealthcheckProbe(result.AST.Children[0])
+		assert.Check(t, is.ErrorContains(err, "is not supported in a Dockerfile"), line)
+	}
+
+	for _, line := range []string{
+		"HEALTHCHECK NONE",
+		"HEALTHCHECK CMD curl -f http://localhost/",
+		"ONBUILD HEALTHCHECK CMD true",
+	} {
+		result, err := parser.Parse(strings.NewReader(line))
+		assert.NilError(t, err)
+		assert.Check(t, checkHealthcheckProbe(result.AST.Children[0]), line)
+	}
+}
+
 func TestEntrypoint(t *testing.T) {
 	b := newBuilderWithMockBackend()
 	sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
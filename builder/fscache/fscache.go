@@ -158,9 +158,16 @@ func (fsc *FSCache) DiskUsage(ctx context.Context) (int64, error) {
 	return fsc.store.DiskUsage(ctx)
 }
 
-// Prune allows manually cleaning up the cache
-func (fsc *FSCache) Prune(ctx context.Context) (uint64, error) {
-	return fsc.store.Prune(ctx)
+// Prune allows manually cleaning up the cache. If dryRun is true, nothing
+// is deleted, and the size which would be reclaimed is returned.
+func (fsc *FSCache) Prune(ctx context.Context, dryRun bool) (uint64, error) {
+	return fsc.store.Prune(ctx, dryRun)
+}
+
+// GC removes the unused cache according to the policy, instead of the
+// policy of the periodic garbage collection, and returns the size reclaimed.
+func (fsc *FSCache) GC(ctx context.Context, policy GCPolicy) (uint64, error) {
+	return fsc.store.gc(ctx, policy)
 }
 
 // Close stops the gc and closes the persistent db
@@ -400,7 +407,7 @@ func (s *fsCacheStore) DiskUsage(ctx context.Context) (int64, error) {
 }
 
 // Prune allows manually cleaning up the cache
-func (s *fsCacheStore) Prune(ctx context.Context) (uint64, error) {
+func (s *fsCacheStore) Prune(ctx context.Context, dryRun bool) (uint64, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	var size uint64
@@ -418,8 +425,10 @@ func (s *fsCacheStore) Prune(ctx context.Context) (uint64, error) {
 			if err != nil {
 				return size, err
 			}
-			if err := s.delete(id); err != nil {
-				return size, errors.Wrapf(err, "failed to delete %s", id)
+			if !dryRun {
+				if err := s.delete(id); err != nil {
+					return size, errors.Wrapf(err, "failed to delete %s", id)
+				}
 			}
 			size += uint64(ss)
 		}
@@ -429,25 +438,33 @@ func (s *fsCacheStore) Prune(ctx context.Context) (uint64, error) {
 
 // GC runs a garbage collector on FSCache
 func (s *fsCacheStore) GC() error {
+	_, err := s.gc(context.Background(), s.gcPolicy)
+	return err
+}
+
+// gc removes the unused sources not used within the MaxKeepDuration of the
+// policy, if set, then the least recently used ones until the size of the
+// unused sources is at most MaxSize. It returns the size reclaimed.
+func (s *fsCacheStore) gc(ctx context.Context, policy GCPolicy) (uint64, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	var size uint64
+	var size, reclaimed uint64
 
-	ctx := context.Background()
-	cutoff := time.Now().Add(-s.gcPolicy.MaxKeepDuration)
+	cutoff := time.Now().Add(-policy.MaxKeepDuration)
 	var blacklist []*cachedSource
 
 	for id, snap := range s.sources {
 		if len(snap.refs) == 0 {
-			if cutoff.After(snap.CachePolicy.LastUsed) {
+			ss, err := snap.getSize(ctx)
+			if err != nil {
+				return reclaimed, err
+			}
+			if policy.MaxKeepDuration > 0 && cutoff.After(snap.CachePolicy.LastUsed) {
 				if err := s.delete(id); err != nil {
-					return errors.Wrapf(err, "failed to delete %s", id)
+					return reclaimed, errors.Wrapf(err, "failed to delete %s", id)
 				}
+				reclaimed += uint64(ss)
 			} else {
-				ss, err := snap.getSize(ctx)
-				if err != nil {
-					return err
-				}
 				size += uint64(ss)
 				blacklist = append(blacklist, snap)
 			}
@@ -456,19 +473,20 @@ func (s *fsCacheStore) GC() error {
 
 	sort.Sort(sortableCacheSources(blacklist))
 	for _, snap := range blacklist {
-		if size <= s.gcPolicy.MaxSize {
+		if size <= policy.MaxSize {
 			break
 		}
 		ss, err := snap.getSize(ctx)
 		if err != nil {
-			return err
+			return reclaimed, err
 		}
 		if err := s.delete(snap.id); err != nil {
-			return errors.Wrapf(err, "failed to delete %s", snap.id)
+			return reclaimed, errors.Wrapf(err, "failed to delete %s", snap.id)
 		}
 		size -= uint64(ss)
+		reclaimed += uint64(ss)
 	}
-	return nil
+	return reclaimed, nil
 }
 
 // keep mu while calling this
@@ -97,8 +97,17 @@ func TestFSCache(t *testing.T) {
 	assert.Check(t, err)
 	assert.Check(t, is.Equal(s, int64(8)))
 
+	// dry-run prune reports everything, but deletes nothing
+	released, err := fscache.Prune(context.TODO(), true)
+	assert.Check(t, err)
+	assert.Check(t, is.Equal(released, uint64(8)))
+
+	s, err = fscache.DiskUsage(context.TODO())
+	assert.Check(t, err)
+	assert.Check(t, is.Equal(s, int64(8)))
+
 	// prune deletes everything
-	released, err := fscache.Prune(context.TODO())
+	released, err = fscache.Prune(context.TODO(), false)
 	assert.Check(t, err)
 	assert.Check(t, is.Equal(released, uint64(8)))
 
@@ -107,6 +116,51 @@ func TestFSCache(t *testing.T) {
 	assert.Check(t, is.Equal(s, int64(0)))
 }
 
+func TestFSCacheGCWithPolicy(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "fscache")
+	assert.Check(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	opt := Opt{
+		Root:     tmpDir,
+		Backend:  NewNaiveCacheBackend(filepath.Join(tmpDir, "backend")),
+		GCPolicy: GCPolicy{MaxSize: 1024, MaxKeepDuration: time.Hour},
+	}
+	fscache, err := NewFSCache(opt)
+	assert.Check(t, err)
+	defer fscache.Close()
+
+	assert.Check(t, fscache.RegisterTransport("test", &testTransport{}))
+
+	for _, id := range []*testIdentifier{
+		{"foo", "data", "foo"},
+		{"bar", "datadata", "bar"},
+	} {
+		src, err := fscache.SyncFrom(context.TODO(), id)
+		assert.Check(t, err)
+		assert.Check(t, src.Close())
+	}
+
+	s, err := fscache.DiskUsage(context.TODO())
+	assert.Check(t, err)
+	assert.Check(t, is.Equal(s, int64(12)))
+
+	// the least recently used source is removed first
+	released, err := fscache.GC(context.TODO(), GCPolicy{MaxSize: 10, MaxKeepDuration: time.Hour})
+	assert.Check(t, err)
+	assert.Check(t, is.Equal(released, uint64(4)))
+
+	s, err = fscache.DiskUsage(context.TODO())
+	assert.Check(t, err)
+	assert.Check(t, is.Equal(s, int64(8)))
+
+	// sources older than the keep duration are removed whatever the size
+	time.Sleep(10 * time.Millisecond)
+	released, err = fscache.GC(context.TODO(), GCPolicy{MaxSize: 1024, MaxKeepDuration: time.Millisecond})
+	assert.Check(t, err)
+	assert.Check(t, is.Equal(released, uint64(8)))
+}
+
 type testTransport struct {
 }
 
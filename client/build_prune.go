@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
 )
@@ -13,10 +14,27 @@ func (cli *Client) BuildCachePrune(ctx context.Context) (*types.BuildCachePruneR
 	if err := cli.NewVersionError("1.31", "build prune"); err != nil {
 		return nil, err
 	}
+	return cli.buildCachePrune(ctx, false)
+}
 
+// BuildCachePruneDryRun requests the daemon to report the cache data which
+// BuildCachePrune would delete, without deleting it
+func (cli *Client) BuildCachePruneDryRun(ctx context.Context) (*types.BuildCachePruneReport, error) {
+	if err := cli.NewVersionError("1.39", "build prune dry-run"); err != nil {
+		return nil, err
+	}
+	return cli.buildCachePrune(ctx, true)
+}
+
+func (cli *Client) buildCachePrune(ctx context.Context, dryRun bool) (*types.BuildCachePruneReport, error) {
 	report := types.BuildCachePruneReport{}
 
-	serverResp, err := cli.post(ctx, "/build/prune", nil, nil, nil)
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry-run", "1")
+	}
+
+	serverResp, err := cli.post(ctx, "/build/prune", query, nil, nil)
 	if err != nil {
 		return nil, err
 	}
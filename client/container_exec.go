@@ -3,8 +3,10 @@ package client // import "github.com/docker/docker/client"
 import (
 	"context"
 	"encoding/json"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 )
 
 // ContainerExecCreate creates a new exec configuration to run an exec process.
@@ -14,6 +16,9 @@ func (cli *Client) ContainerExecCreate(ctx context.Context, container string, co
 	if err := cli.NewVersionError("1.25", "env"); len(config.Env) != 0 && err != nil {
 		return response, err
 	}
+	if err := cli.NewVersionError("1.39", "timeout"); config.Timeout != 0 && err != nil {
+		return response, err
+	}
 
 	resp, err := cli.post(ctx, "/containers/"+container+"/exec", nil, config, nil)
 	if err != nil {
@@ -52,3 +57,56 @@ func (cli *Client) ContainerExecInspect(ctx context.Context, execID string) (typ
 	ensureReaderClosed(resp)
 	return response, err
 }
+
+// ContainerExecList returns the exec processes of a container, including the
+// processes which have exited and are not cleaned up yet.
+func (cli *Client) ContainerExecList(ctx context.Context, container string) ([]types.ContainerExecInspect, error) {
+	if err := cli.NewVersionError("1.39", "exec list"); err != nil {
+		return nil, err
+	}
+
+	var response []types.ContainerExecInspect
+	resp, err := cli.get(ctx, "/containers/"+container+"/execs", nil, nil)
+	if err != nil {
+		return response, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response, err
+}
+
+// ContainerExecKill sends a signal to an exec process. The process is killed
+// if signal is empty.
+func (cli *Client) ContainerExecKill(ctx context.Context, execID, signal string) error {
+	if err := cli.NewVersionError("1.39", "exec kill"); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if signal != "" {
+		query.Set("signal", signal)
+	}
+
+	resp, err := cli.post(ctx, "/exec/"+execID+"/kill", query, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
+
+// ContainerExecWait waits until an exec process exits, and returns its exit
+// code.
+func (cli *Client) ContainerExecWait(ctx context.Context, execID string) (container.ContainerWaitOKBody, error) {
+	var response container.ContainerWaitOKBody
+	if err := cli.NewVersionError("1.39", "exec wait"); err != nil {
+		return response, err
+	}
+
+	resp, err := cli.post(ctx, "/exec/"+execID+"/wait", nil, nil, nil)
+	if err != nil {
+		return response, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response, err
+}
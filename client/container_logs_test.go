@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
 )
@@ -101,6 +102,17 @@ func TestContainerLogs(t *testing.T) {
 				"until": "1136073600.000000001",
 			},
 		},
+		{
+			options: types.ContainerLogsOptions{
+				Grep:    "^error",
+				Filters: filters.NewArgs(filters.Arg("attr", "tag=web")),
+			},
+			expectedQueryParams: map[string]string{
+				"tail":    "",
+				"grep":    "^error",
+				"filters": `{"attr":{"tag=web":true}}`,
+			},
+		},
 		{
 			options: types.ContainerLogsOptions{
 				// An complete invalid date will not be passed
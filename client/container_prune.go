@@ -11,16 +11,31 @@ import (
 
 // ContainersPrune requests the daemon to delete unused data
 func (cli *Client) ContainersPrune(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error) {
-	var report types.ContainersPruneReport
-
 	if err := cli.NewVersionError("1.25", "container prune"); err != nil {
-		return report, err
+		return types.ContainersPruneReport{}, err
+	}
+	return cli.containersPrune(ctx, pruneFilters, false)
+}
+
+// ContainersPruneDryRun requests the daemon to report the data which
+// ContainersPrune would delete, without deleting it
+func (cli *Client) ContainersPruneDryRun(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error) {
+	if err := cli.NewVersionError("1.39", "container prune dry-run"); err != nil {
+		return types.ContainersPruneReport{}, err
 	}
+	return cli.containersPrune(ctx, pruneFilters, true)
+}
+
+func (cli *Client) containersPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.ContainersPruneReport, error) {
+	var report types.ContainersPruneReport
 
 	query, err := getFiltersQuery(pruneFilters)
 	if err != nil {
 		return report, err
 	}
+	if dryRun {
+		query.Set("dry-run", "1")
+	}
 
 	serverResp, err := cli.post(ctx, "/containers/prune", query, nil, nil)
 	if err != nil {
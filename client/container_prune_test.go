@@ -123,3 +123,39 @@ func TestContainersPrune(t *testing.T) {
 		assert.Check(t, is.Equal(uint64(9999), report.SpaceReclaimed))
 	}
 }
+
+func TestContainersPruneDryRun(t *testing.T) {
+	expectedURL := "/v1.39/containers/prune"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if dryRun := req.URL.Query().Get("dry-run"); dryRun != "1" {
+				return nil, fmt.Errorf("dry-run not set in URL query properly. Expected '1', got %s", dryRun)
+			}
+			content, err := json.Marshal(types.ContainersPruneReport{
+				ContainersDeleted: []string{"container_id1"},
+				SpaceReclaimed:    9999,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+		version: "1.39",
+	}
+
+	report, err := client.ContainersPruneDryRun(context.Background(), filters.NewArgs())
+	assert.Check(t, err)
+	assert.Check(t, is.DeepEqual([]string{"container_id1"}, report.ContainersDeleted))
+	assert.Check(t, is.Equal(uint64(9999), report.SpaceReclaimed))
+
+	client.version = "1.38"
+	_, err = client.ContainersPruneDryRun(context.Background(), filters.NewArgs())
+	assert.Check(t, is.Error(err, `"container prune dry-run" requires API version 1.39, but the Docker daemon API version is 1.38`))
+}
@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 )
 
 // ContainerStats returns near realtime stats for a given container.
@@ -24,3 +25,34 @@ func (cli *Client) ContainerStats(ctx context.Context, containerID string, strea
 	osType := getDockerOS(resp.header.Get("Server"))
 	return types.ContainerStats{Body: resp.body, OSType: osType}, err
 }
+
+// ContainersStats returns near realtime stats for all the running containers
+// matching the filters, multiplexed on a single stream.
+// It's up to the caller to close the io.ReadCloser returned.
+func (cli *Client) ContainersStats(ctx context.Context, filter filters.Args, stream bool) (types.ContainerStats, error) {
+	if err := cli.NewVersionError("1.39", "multiple containers stats"); err != nil {
+		return types.ContainerStats{}, err
+	}
+
+	query := url.Values{}
+	query.Set("stream", "0")
+	if stream {
+		query.Set("stream", "1")
+	}
+
+	if filter.Len() > 0 {
+		filterJSON, err := filters.ToJSON(filter)
+		if err != nil {
+			return types.ContainerStats{}, err
+		}
+		query.Set("filters", filterJSON)
+	}
+
+	resp, err := cli.get(ctx, "/containers/stats", query, nil)
+	if err != nil {
+		return types.ContainerStats{}, err
+	}
+
+	osType := getDockerOS(resp.header.Get("Server"))
+	return types.ContainerStats{Body: resp.body, OSType: osType}, err
+}
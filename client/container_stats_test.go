@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/docker/docker/api/types/filters"
 )
 
 func TestContainerStatsError(t *testing.T) {
@@ -67,3 +69,51 @@ func TestContainerStats(t *testing.T) {
 		}
 	}
 }
+
+func TestContainersStats(t *testing.T) {
+	expectedURL := "/containers/stats"
+	client := &Client{
+		version: "1.39",
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, "/v1.39"+expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+
+			query := r.URL.Query()
+			if stream := query.Get("stream"); stream != "1" {
+				return nil, fmt.Errorf("stream not set in URL query properly. Expected '1', got %s", stream)
+			}
+			if f := query.Get("filters"); f != `{"label":{"app=web":true}}` {
+				return nil, fmt.Errorf("filters not set in URL query properly. Got %s", f)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	resp, err := client.ContainersStats(context.Background(), filters.NewArgs(filters.Arg("label", "app=web")), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "response" {
+		t.Fatalf("expected response to contain 'response', got %s", string(content))
+	}
+}
+
+func TestContainersStatsVersionError(t *testing.T) {
+	client := &Client{
+		version: "1.38",
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainersStats(context.Background(), filters.NewArgs(), false)
+	if err == nil || !strings.Contains(err.Error(), "multiple containers stats") {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
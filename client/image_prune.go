@@ -11,16 +11,31 @@ import (
 
 // ImagesPrune requests the daemon to delete unused data
 func (cli *Client) ImagesPrune(ctx context.Context, pruneFilters filters.Args) (types.ImagesPruneReport, error) {
-	var report types.ImagesPruneReport
-
 	if err := cli.NewVersionError("1.25", "image prune"); err != nil {
-		return report, err
+		return types.ImagesPruneReport{}, err
+	}
+	return cli.imagesPrune(ctx, pruneFilters, false)
+}
+
+// ImagesPruneDryRun requests the daemon to report the data which
+// ImagesPrune would delete, without deleting it
+func (cli *Client) ImagesPruneDryRun(ctx context.Context, pruneFilters filters.Args) (types.ImagesPruneReport, error) {
+	if err := cli.NewVersionError("1.39", "image prune dry-run"); err != nil {
+		return types.ImagesPruneReport{}, err
 	}
+	return cli.imagesPrune(ctx, pruneFilters, true)
+}
+
+func (cli *Client) imagesPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.ImagesPruneReport, error) {
+	var report types.ImagesPruneReport
 
 	query, err := getFiltersQuery(pruneFilters)
 	if err != nil {
 		return report, err
 	}
+	if dryRun {
+		query.Set("dry-run", "1")
+	}
 
 	serverResp, err := cli.post(ctx, "/images/prune", query, nil, nil)
 	if err != nil {
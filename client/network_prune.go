@@ -11,16 +11,31 @@ import (
 
 // NetworksPrune requests the daemon to delete unused networks
 func (cli *Client) NetworksPrune(ctx context.Context, pruneFilters filters.Args) (types.NetworksPruneReport, error) {
-	var report types.NetworksPruneReport
-
 	if err := cli.NewVersionError("1.25", "network prune"); err != nil {
-		return report, err
+		return types.NetworksPruneReport{}, err
+	}
+	return cli.networksPrune(ctx, pruneFilters, false)
+}
+
+// NetworksPruneDryRun requests the daemon to report the data which
+// NetworksPrune would delete, without deleting it
+func (cli *Client) NetworksPruneDryRun(ctx context.Context, pruneFilters filters.Args) (types.NetworksPruneReport, error) {
+	if err := cli.NewVersionError("1.39", "network prune dry-run"); err != nil {
+		return types.NetworksPruneReport{}, err
 	}
+	return cli.networksPrune(ctx, pruneFilters, true)
+}
+
+func (cli *Client) networksPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.NetworksPruneReport, error) {
+	var report types.NetworksPruneReport
 
 	query, err := getFiltersQuery(pruneFilters)
 	if err != nil {
 		return report, err
 	}
+	if dryRun {
+		query.Set("dry-run", "1")
+	}
 
 	serverResp, err := cli.post(ctx, "/networks/prune", query, nil, nil)
 	if err != nil {
@@ -41,6 +41,15 @@ func TestServiceList(t *testing.T) {
 			options: types.ServiceListOptions{},
 			expectedQueryParams: map[string]string{
 				"filters": "",
+				"status":  "",
+			},
+		},
+		{
+			options: types.ServiceListOptions{
+				Status: true,
+			},
+			expectedQueryParams: map[string]string{
+				"status": "true",
 			},
 		},
 		{
@@ -0,0 +1,78 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVolumeCloneUnsupported(t *testing.T) {
+	client := &Client{
+		version: "1.38",
+		client:  &http.Client{},
+	}
+	_, err := client.VolumeClone(context.Background(), "volume_id", volumetypes.VolumeCloneBody{})
+	assert.Check(t, is.Error(err, `"volume clone" requires API version 1.39, but the Docker daemon API version is 1.38`))
+}
+
+func TestVolumeCloneNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
+	}
+
+	_, err := client.VolumeClone(context.Background(), "unknown", volumetypes.VolumeCloneBody{})
+	assert.Check(t, IsErrNotFound(err))
+}
+
+func TestVolumeClone(t *testing.T) {
+	expectedURL := "/volumes/volume_id/clone"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+
+			var options volumetypes.VolumeCloneBody
+			if err := json.NewDecoder(req.Body).Decode(&options); err != nil {
+				return nil, err
+			}
+			if options.Name != "clone" {
+				return nil, fmt.Errorf("expected name 'clone', got %s", options.Name)
+			}
+
+			content, err := json.Marshal(types.Volume{
+				Name:   options.Name,
+				Driver: "local",
+				Labels: options.Labels,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	volume, err := client.VolumeClone(context.Background(), "volume_id", volumetypes.VolumeCloneBody{
+		Name:   "clone",
+		Labels: map[string]string{"branch": "feature"},
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("clone", volume.Name))
+	assert.Check(t, is.DeepEqual(map[string]string{"branch": "feature"}, volume.Labels))
+}
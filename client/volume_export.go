@@ -0,0 +1,27 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// VolumeExport retrieves the content of a volume as a tar archive, compressed
+// with the given compression ("none", "gzip" or "zstd"; the empty string
+// means no compression).
+// It's up to the caller to close the io.ReadCloser returned by this function.
+func (cli *Client) VolumeExport(ctx context.Context, volumeID, compression string) (io.ReadCloser, error) {
+	if err := cli.NewVersionError("1.39", "volume export"); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if compression != "" {
+		query.Set("compression", compression)
+	}
+	resp, err := cli.get(ctx, "/volumes/"+volumeID+"/export", query, nil)
+	if err != nil {
+		return nil, wrapResponseError(err, resp, "volume", volumeID)
+	}
+	return resp.body, nil
+}
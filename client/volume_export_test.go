@@ -0,0 +1,62 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVolumeExportUnsupported(t *testing.T) {
+	client := &Client{
+		version: "1.38",
+		client:  &http.Client{},
+	}
+	_, err := client.VolumeExport(context.Background(), "volume_id", "")
+	assert.Check(t, is.Error(err, `"volume export" requires API version 1.39, but the Docker daemon API version is 1.38`))
+}
+
+func TestVolumeExportError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.VolumeExport(context.Background(), "volume_id", "")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestVolumeExport(t *testing.T) {
+	expectedURL := "/volumes/volume_id/export"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+			}
+			if compression := req.URL.Query().Get("compression"); compression != "gzip" {
+				return nil, fmt.Errorf("compression not set in URL query properly. Expected 'gzip', got %s", compression)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+
+	rdr, err := client.VolumeExport(context.Background(), "volume_id", "gzip")
+	assert.NilError(t, err)
+	defer rdr.Close()
+	b, err := ioutil.ReadAll(rdr)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("response", string(b)))
+}
@@ -0,0 +1,19 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"io"
+)
+
+// VolumeImport extracts a tar archive, which may be compressed, to an
+// existing volume.
+func (cli *Client) VolumeImport(ctx context.Context, volumeID string, input io.Reader) error {
+	if err := cli.NewVersionError("1.39", "volume import"); err != nil {
+		return err
+	}
+
+	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
+	resp, err := cli.postRaw(ctx, "/volumes/"+volumeID+"/import", nil, input, headers)
+	defer ensureReaderClosed(resp)
+	return wrapResponseError(err, resp, "volume", volumeID)
+}
@@ -0,0 +1,63 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVolumeImportUnsupported(t *testing.T) {
+	client := &Client{
+		version: "1.38",
+		client:  &http.Client{},
+	}
+	err := client.VolumeImport(context.Background(), "volume_id", strings.NewReader(""))
+	assert.Check(t, is.Error(err, `"volume import" requires API version 1.39, but the Docker daemon API version is 1.38`))
+}
+
+func TestVolumeImportNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
+	}
+
+	err := client.VolumeImport(context.Background(), "unknown", strings.NewReader(""))
+	assert.Check(t, IsErrNotFound(err))
+}
+
+func TestVolumeImport(t *testing.T) {
+	expectedURL := "/volumes/volume_id/import"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			if contentType := req.Header.Get("Content-Type"); contentType != "application/x-tar" {
+				return nil, fmt.Errorf("Content-type header not set properly. Expected 'application/x-tar', got %s", contentType)
+			}
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			if string(b) != "archive" {
+				return nil, fmt.Errorf("expected body 'archive', got %q", string(b))
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}),
+	}
+
+	err := client.VolumeImport(context.Background(), "volume_id", strings.NewReader("archive"))
+	assert.NilError(t, err)
+}
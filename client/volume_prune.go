@@ -11,16 +11,31 @@ import (
 
 // VolumesPrune requests the daemon to delete unused data
 func (cli *Client) VolumesPrune(ctx context.Context, pruneFilters filters.Args) (types.VolumesPruneReport, error) {
-	var report types.VolumesPruneReport
-
 	if err := cli.NewVersionError("1.25", "volume prune"); err != nil {
-		return report, err
+		return types.VolumesPruneReport{}, err
+	}
+	return cli.volumesPrune(ctx, pruneFilters, false)
+}
+
+// VolumesPruneDryRun requests the daemon to report the data which
+// VolumesPrune would delete, without deleting it
+func (cli *Client) VolumesPruneDryRun(ctx context.Context, pruneFilters filters.Args) (types.VolumesPruneReport, error) {
+	if err := cli.NewVersionError("1.39", "volume prune dry-run"); err != nil {
+		return types.VolumesPruneReport{}, err
 	}
+	return cli.volumesPrune(ctx, pruneFilters, true)
+}
+
+func (cli *Client) volumesPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.VolumesPruneReport, error) {
+	var report types.VolumesPruneReport
 
 	query, err := getFiltersQuery(pruneFilters)
 	if err != nil {
 		return report, err
 	}
+	if dryRun {
+		query.Set("dry-run", "1")
+	}
 
 	serverResp, err := cli.post(ctx, "/volumes/prune", query, nil, nil)
 	if err != nil {
@@ -53,6 +53,18 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.Var(opts.NewNamedListOptsRef("labels", &conf.Labels, opts.ValidateLabel), "label", "Set key=value labels to the daemon")
 	flags.StringVar(&conf.LogConfig.Type, "log-driver", "json-file", "Default driver for container logs")
 	flags.Var(opts.NewNamedMapOpts("log-opts", conf.LogConfig.Config, nil), "log-opt", "Default log driver options for containers")
+	flags.BoolVar(&conf.EventsJournalConfig.Enabled, "events-journal", false, "Persist events to disk so they can be replayed after a restart")
+	conf.EventsJournalConfig.MaxSize = opts.MemBytes(config.DefaultEventsJournalMaxSize)
+	flags.Var(&conf.EventsJournalConfig.MaxSize, "events-journal-max-size", "Maximum size of each events journal file")
+	flags.IntVar(&conf.EventsJournalConfig.MaxFiles, "events-journal-max-files", config.DefaultEventsJournalMaxFiles, "Maximum number of events journal files to keep")
+	flags.Var(&conf.EventSinks, "event-sink", "Forward events to a webhook, unix socket or file")
+	conf.GCConfig.Interval = opts.Duration(config.DefaultGCInterval)
+	flags.Var(&conf.GCConfig.Interval, "gc-interval", "Interval of the garbage collection of unused images and build cache")
+	flags.Var(&conf.GCConfig.MaxImagesSize, "gc-max-images-size", "Remove the least recently used unused images when the images use more space")
+	flags.Var(&conf.GCConfig.BuildCacheKeepStorage, "gc-build-cache-keep-storage", "Remove the least recently used build cache when it uses more space")
+	flags.Var(&conf.GCConfig.MinFreeSpace, "gc-min-free-space", "Remove unused build cache and images when the free space of the data root is lower")
+	flags.Var(&conf.GCConfig.MaxAge, "gc-max-age", "Remove unused images and build cache not used for longer")
+	flags.Var(&conf.GCConfig.MinAge, "gc-min-age", "Keep unused images used more recently, whatever the space they use")
 	flags.StringVar(&conf.ClusterAdvertise, "cluster-advertise", "", "Address or interface name to advertise")
 	flags.StringVar(&conf.ClusterStore, "cluster-store", "", "URL of the distributed storage backend")
 	flags.Var(opts.NewNamedMapOpts("cluster-store-opts", conf.ClusterOpts, nil), "cluster-store-opt", "Set cluster store options")
@@ -67,6 +79,8 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.BoolVar(&conf.Experimental, "experimental", false, "Enable experimental features")
 
 	flags.StringVar(&conf.MetricsAddress, "metrics-addr", "", "Set default address and port to serve the metrics api on")
+	flags.BoolVar(&conf.ContainerMetrics, "container-metrics", false, "Serve per-container resource metrics on the metrics api")
+	flags.Var(opts.NewNamedListOptsRef("container-metrics-labels", &conf.ContainerMetricsLabels, nil), "container-metrics-label", "Container label to add to the per-container metrics")
 
 	flags.Var(opts.NewNamedListOptsRef("node-generic-resources", &conf.NodeGenericResources, opts.ValidateSingleGenericResource), "node-generic-resource", "Advertise user-defined resource")
 
@@ -22,6 +22,8 @@ import (
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	"github.com/docker/docker/daemon/logger/local"
+	"github.com/docker/docker/daemon/logger/loggerutils/cache"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -384,11 +386,36 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 		container.LogPath = info.LogPath
 	}
 
+	// Set logging file for "local"
+	if cfg.Type == local.Name {
+		info.LogPath, err = container.GetRootResourcePath(filepath.Join("container-logs", "container.log"))
+		if err != nil {
+			return nil, err
+		}
+
+		container.LogPath = info.LogPath
+	}
+
 	l, err := initDriver(info)
 	if err != nil {
 		return nil, err
 	}
 
+	if _, ok := l.(logger.LogReader); !ok && cache.ShouldUseCache(cfg.Config) {
+		info.LogPath, err = container.GetRootResourcePath(filepath.Join("container-cached-logs", "container.log"))
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		logrus.WithField("container", container.ID).WithField("driver", cfg.Type).Debug("Configured log driver does not support reads, enabling local file cache for container logs")
+		cached, err := cache.WithLocalCache(l, info)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l = cached
+	}
+
 	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
 		bufferSize := int64(-1)
 		if s, exists := cfg.Config["max-buffer-size"]; exists {
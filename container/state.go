@@ -32,6 +32,7 @@ type State struct {
 	StartedAt         time.Time
 	FinishedAt        time.Time
 	Health            *Health
+	CrashLooping      bool // true while restarts are delayed by the maximum backoff of the restart policy, until the container runs for longer than the reset window
 
 	waitStop   chan struct{}
 	waitRemove chan struct{}
@@ -284,6 +285,7 @@ func (s *State) SetStopped(exitStatus *ExitStatus) {
 	s.Running = false
 	s.Paused = false
 	s.Restarting = false
+	s.CrashLooping = false
 	s.Pid = 0
 	if exitStatus.ExitedAt.IsZero() {
 		s.FinishedAt = time.Now().UTC()
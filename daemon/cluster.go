@@ -3,6 +3,7 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	apitypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
 	lncluster "github.com/docker/libnetwork/cluster"
 )
 
@@ -11,6 +12,7 @@ type Cluster interface {
 	ClusterStatus
 	NetworkManager
 	SendClusterEvent(event lncluster.ConfigEventType)
+	GetServices(apitypes.ServiceListOptions) ([]swarm.Service, error)
 }
 
 // ClusterStatus interface provides information about the Swarm status of the Cluster
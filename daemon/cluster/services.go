@@ -95,6 +95,12 @@ func (c *Cluster) GetServices(options apitypes.ServiceListOptions) ([]types.Serv
 		services = append(services, svcs)
 	}
 
+	if options.Status {
+		if err := setServiceStatus(ctx, state.controlClient, services); err != nil {
+			return nil, err
+		}
+	}
+
 	return services, nil
 }
 
@@ -118,6 +124,53 @@ func (c *Cluster) GetService(input string, insertDefaults bool) (types.Service,
 	return svc, nil
 }
 
+// setServiceStatus sets the status of the services, computed from their
+// tasks.
+func setServiceStatus(ctx context.Context, client swarmapi.ControlClient, services []types.Service) error {
+	if len(services) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(services))
+	for _, s := range services {
+		ids = append(ids, s.ID)
+	}
+
+	r, err := client.ListTasks(ctx, &swarmapi.ListTasksRequest{
+		Filters: &swarmapi.ListTasksRequest_Filters{ServiceIDs: ids},
+	})
+	if err != nil {
+		return err
+	}
+	status := serviceStatusFromTasks(r.Tasks)
+	for i, s := range services {
+		st := status[s.ID]
+		if s.Spec.Mode.Replicated != nil && s.Spec.Mode.Replicated.Replicas != nil {
+			st.DesiredTasks = *s.Spec.Mode.Replicated.Replicas
+		}
+		services[i].ServiceStatus = &st
+	}
+	return nil
+}
+
+// serviceStatusFromTasks counts the tasks of each service. Tasks which have
+// been replaced, for example failed tasks which have been restarted, are not
+// counted. The desired tasks are the tasks which have not been replaced.
+func serviceStatusFromTasks(tasks []*swarmapi.Task) map[string]types.ServiceStatus {
+	status := make(map[string]types.ServiceStatus)
+	for _, t := range tasks {
+		if t.DesiredState > swarmapi.TaskStateRunning {
+			continue
+		}
+		st := status[t.ServiceID]
+		st.DesiredTasks++
+		if t.Status.State == swarmapi.TaskStateRunning {
+			st.RunningTasks++
+		}
+		status[t.ServiceID] = st
+	}
+	return status
+}
+
 // CreateService creates a new service in a managed swarm cluster.
 func (c *Cluster) CreateService(s types.ServiceSpec, encodedAuth string, queryRegistry bool) (*apitypes.ServiceCreateResponse, error) {
 	var resp *apitypes.ServiceCreateResponse
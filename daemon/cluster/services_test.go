@@ -0,0 +1,36 @@
+package cluster // import "github.com/docker/docker/daemon/cluster"
+
+import (
+	"testing"
+
+	types "github.com/docker/docker/api/types/swarm"
+	swarmapi "github.com/docker/swarmkit/api"
+	"gotest.tools/assert"
+)
+
+func TestServiceStatusFromTasks(t *testing.T) {
+	task := func(serviceID string, desired, state swarmapi.TaskState) *swarmapi.Task {
+		return &swarmapi.Task{
+			ServiceID:    serviceID,
+			DesiredState: desired,
+			Status:       swarmapi.TaskStatus{State: state},
+		}
+	}
+	tasks := []*swarmapi.Task{
+		task("replicated", swarmapi.TaskStateRunning, swarmapi.TaskStateRunning),
+		task("replicated", swarmapi.TaskStateRunning, swarmapi.TaskStatePending),
+		task("replicated", swarmapi.TaskStateRunning, swarmapi.TaskStateRejected),
+		// Removed when the service was scaled down.
+		task("replicated", swarmapi.TaskStateRemove, swarmapi.TaskStateRunning),
+		task("global", swarmapi.TaskStateRunning, swarmapi.TaskStateRunning),
+		task("global", swarmapi.TaskStateRunning, swarmapi.TaskStateFailed),
+		// Restarted after a failure.
+		task("global", swarmapi.TaskStateShutdown, swarmapi.TaskStateFailed),
+	}
+
+	status := serviceStatusFromTasks(tasks)
+	assert.DeepEqual(t, map[string]types.ServiceStatus{
+		"replicated": {RunningTasks: 1, DesiredTasks: 3},
+		"global":     {RunningTasks: 1, DesiredTasks: 2},
+	}, status)
+}
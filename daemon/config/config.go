@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	daemondiscovery "github.com/docker/docker/daemon/discovery"
 	"github.com/docker/docker/opts"
@@ -43,6 +44,12 @@ const (
 	DisableNetworkBridge = "none"
 	// DefaultInitBinary is the name of the default init binary
 	DefaultInitBinary = "docker-init"
+	// DefaultEventsJournalMaxSize is the default maximum size of each file of the events journal
+	DefaultEventsJournalMaxSize = int64(10 * 1024 * 1024)
+	// DefaultEventsJournalMaxFiles is the default maximum number of files of the events journal
+	DefaultEventsJournalMaxFiles = 5
+	// DefaultGCInterval is the default interval of the garbage collection of unused images and build cache
+	DefaultGCInterval = time.Hour
 )
 
 // flatOptions contains configuration keys
@@ -64,6 +71,34 @@ type LogConfig struct {
 	Config map[string]string `json:"log-opts,omitempty"`
 }
 
+// EventsJournalConfig represents the configuration of the on-disk events journal.
+// It includes json tags to deserialize configuration from a file
+// using the same names that the flags in the command line use.
+type EventsJournalConfig struct {
+	Enabled  bool          `json:"events-journal,omitempty"`
+	MaxSize  opts.MemBytes `json:"events-journal-max-size,omitempty"`
+	MaxFiles int           `json:"events-journal-max-files,omitempty"`
+}
+
+// GCConfig represents the configuration of the periodic garbage collection
+// of unused images and build cache. The garbage collection is disabled
+// unless at least one of the size, free space or age thresholds is set.
+// It includes json tags to deserialize configuration from a file
+// using the same names that the flags in the command line use.
+type GCConfig struct {
+	Interval              opts.Duration `json:"gc-interval,omitempty"`
+	MaxImagesSize         opts.MemBytes `json:"gc-max-images-size,omitempty"`
+	BuildCacheKeepStorage opts.MemBytes `json:"gc-build-cache-keep-storage,omitempty"`
+	MinFreeSpace          opts.MemBytes `json:"gc-min-free-space,omitempty"`
+	MaxAge                opts.Duration `json:"gc-max-age,omitempty"`
+	MinAge                opts.Duration `json:"gc-min-age,omitempty"`
+}
+
+// Enabled returns true if a threshold of the garbage collection is set.
+func (c GCConfig) Enabled() bool {
+	return c.MaxImagesSize > 0 || c.BuildCacheKeepStorage > 0 || c.MinFreeSpace > 0 || c.MaxAge > 0
+}
+
 // commonBridgeConfig stores all the platform-common bridge driver specific
 // configuration.
 type commonBridgeConfig struct {
@@ -179,7 +214,21 @@ type CommonConfig struct {
 
 	MetricsAddress string `json:"metrics-addr"`
 
+	// ContainerMetrics enables the per-container resource metrics on the
+	// metrics endpoint.
+	ContainerMetrics bool `json:"container-metrics,omitempty"`
+
+	// ContainerMetricsLabels are the keys of the container labels added as
+	// labels to the per-container metrics.
+	ContainerMetricsLabels []string `json:"container-metrics-labels,omitempty"`
+
 	LogConfig
+	EventsJournalConfig
+	GCConfig
+
+	// EventSinks are the sinks every event is forwarded to.
+	EventSinks opts.EventSinksOpt `json:"event-sinks,omitempty"`
+
 	BridgeConfig // bridgeConfig holds bridge network specific configuration.
 	NetworkConfig
 	registry.ServiceOptions
@@ -534,6 +583,28 @@ func Validate(config *Config) error {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
 
+	// validate the events journal retention
+	if config.EventsJournalConfig.MaxSize < 0 {
+		return fmt.Errorf("invalid events journal max size: %d", config.EventsJournalConfig.MaxSize)
+	}
+	if config.EventsJournalConfig.MaxFiles < 0 || (config.EventsJournalConfig.Enabled && config.EventsJournalConfig.MaxFiles < 1) {
+		return fmt.Errorf("invalid events journal max files: %d", config.EventsJournalConfig.MaxFiles)
+	}
+
+	// validate the garbage collection thresholds
+	if config.GCConfig.MaxImagesSize < 0 {
+		return fmt.Errorf("invalid gc max images size: %d", config.GCConfig.MaxImagesSize)
+	}
+	if config.GCConfig.BuildCacheKeepStorage < 0 {
+		return fmt.Errorf("invalid gc build cache keep storage: %d", config.GCConfig.BuildCacheKeepStorage)
+	}
+	if config.GCConfig.MinFreeSpace < 0 {
+		return fmt.Errorf("invalid gc min free space: %d", config.GCConfig.MinFreeSpace)
+	}
+	if config.GCConfig.MaxAge > 0 && config.GCConfig.MinAge > config.GCConfig.MaxAge {
+		return fmt.Errorf("gc min age (%s) must not be greater than gc max age (%s)", config.GCConfig.MinAge.Value(), config.GCConfig.MaxAge.Value())
+	}
+
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
 		if _, ok := runtimes[StockRuntimeName]; ok {
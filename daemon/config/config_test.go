@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/daemon/discovery"
 	"github.com/docker/docker/opts"
@@ -305,6 +306,16 @@ func TestValidateConfigurationErrors(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					EventsJournalConfig: EventsJournalConfig{
+						Enabled:  true,
+						MaxFiles: 0,
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -376,6 +387,16 @@ func TestValidateConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					EventsJournalConfig: EventsJournalConfig{
+						Enabled:  true,
+						MaxFiles: 1,
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -516,3 +537,48 @@ func TestReloadWithDuplicateLabels(t *testing.T) {
 	err := Reload(configFile, flags, func(c *Config) {})
 	assert.Check(t, err)
 }
+
+func TestDaemonConfigurationMergeEventSinks(t *testing.T) {
+	configFile := fs.NewFile(t, "config", fs.WithContent(`{"event-sinks": [{"type": "webhook", "address": "http://127.0.0.1:8080/events", "filters": ["type=container"]}]}`))
+	defer configFile.Remove()
+
+	var sinks opts.EventSinksOpt
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Var(&sinks, "event-sink", "")
+
+	cc, err := MergeDaemonConfigurations(&Config{}, flags, configFile.Path())
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(cc.EventSinks.Value(), 1))
+	assert.Check(t, is.Equal("webhook", cc.EventSinks.Value()[0].Type))
+	assert.Check(t, is.DeepEqual([]string{"type=container"}, cc.EventSinks.Value()[0].Filters))
+
+	configFile = fs.NewFile(t, "config", fs.WithContent(`{"event-sinks": [{"type": "syslog", "address": "/dev/log"}]}`))
+	defer configFile.Remove()
+	_, err = MergeDaemonConfigurations(&Config{}, flags, configFile.Path())
+	assert.Check(t, is.ErrorContains(err, "unknown event sink type"))
+}
+
+func TestDaemonConfigurationMergeGC(t *testing.T) {
+	configFile := fs.NewFile(t, "config", fs.WithContent(`{"gc-interval": "30m", "gc-max-images-size": "10g", "gc-max-age": "720h", "gc-min-age": "1h"}`))
+	defer configFile.Remove()
+
+	conf := &Config{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Var(&conf.GCConfig.Interval, "gc-interval", "")
+	flags.Var(&conf.GCConfig.MaxImagesSize, "gc-max-images-size", "")
+	flags.Var(&conf.GCConfig.MaxAge, "gc-max-age", "")
+	flags.Var(&conf.GCConfig.MinAge, "gc-min-age", "")
+
+	cc, err := MergeDaemonConfigurations(conf, flags, configFile.Path())
+	assert.NilError(t, err)
+	assert.Check(t, cc.GCConfig.Enabled())
+	assert.Check(t, is.Equal(30*time.Minute, cc.GCConfig.Interval.Value()))
+	assert.Check(t, is.Equal(int64(10*1024*1024*1024), cc.GCConfig.MaxImagesSize.Value()))
+	assert.Check(t, is.Equal(720*time.Hour, cc.GCConfig.MaxAge.Value()))
+	assert.Check(t, is.Equal(time.Hour, cc.GCConfig.MinAge.Value()))
+
+	configFile = fs.NewFile(t, "config", fs.WithContent(`{"gc-max-age": "1h", "gc-min-age": "2h"}`))
+	defer configFile.Remove()
+	_, err = MergeDaemonConfigurations(&Config{}, flags, configFile.Path())
+	assert.Check(t, is.ErrorContains(err, "gc min age (2h0m0s) must not be greater than gc max age (1h0m0s)"))
+}
@@ -132,3 +132,13 @@ func TestDaemonConfigurationMergeShmSize(t *testing.T) {
 	expectedValue := 1 * 1024 * 1024 * 1024
 	assert.Check(t, is.Equal(int64(expectedValue), cc.ShmSize.Value()))
 }
+
+func TestValidatePlatformConfigCgroupNamespaceMode(t *testing.T) {
+	for _, mode := range []string{"", "host", "private"} {
+		c := &Config{CgroupNamespaceMode: mode}
+		assert.Check(t, c.ValidatePlatformConfig(), "mode %q", mode)
+	}
+
+	c := &Config{CgroupNamespaceMode: "shareable"}
+	assert.Check(t, is.ErrorContains(c.ValidatePlatformConfig(), "Default cgroup namespace mode setting (shareable) is invalid"))
+}
@@ -190,6 +190,9 @@ func (daemon *Daemon) create(params types.ContainerCreateConfig, managed bool) (
 		return nil, err
 	}
 	stateCtr.set(container.ID, "stopped")
+	if img != nil {
+		daemon.imageService.SetImageLastUsed(imgID)
+	}
 	daemon.LogContainerEvent(container, "create")
 	return container, nil
 }
@@ -53,6 +53,7 @@ import (
 	"github.com/docker/docker/registry"
 	"github.com/docker/docker/runconfig"
 	volumesservice "github.com/docker/docker/volume/service"
+	"github.com/docker/go-metrics"
 	"github.com/docker/libnetwork"
 	"github.com/docker/libnetwork/cluster"
 	nwconfig "github.com/docker/libnetwork/config"
@@ -77,6 +78,8 @@ type Daemon struct {
 	idIndex           *truncindex.TruncIndex
 	configStore       *config.Config
 	statsCollector    *stats.Collector
+	containerMetrics  *containerMetrics
+	gcStop            chan struct{}
 	defaultLogConfig  containertypes.LogConfig
 	RegistryService   registry.Service
 	EventsService     *events.Events
@@ -86,6 +89,7 @@ type Daemon struct {
 	root              string
 	seccompEnabled    bool
 	apparmorEnabled   bool
+	cgroupUnified     bool
 	shutdown          bool
 	idMappings        *idtools.IDMappings
 	// TODO: move graphDrivers field to an InfoService
@@ -103,6 +107,8 @@ type Daemon struct {
 
 	machineMemory uint64
 
+	oomControlPaths sync.Map // container ID -> oomControlPath, caches the memory cgroup of containers for stats
+
 	seccompProfile     []byte
 	seccompProfilePath string
 
@@ -299,6 +305,11 @@ func (daemon *Daemon) restore() error {
 				}
 
 				c.ResetRestartManager(false)
+				if c.IsRunning() {
+					c.Lock()
+					daemon.clearCrashLoop(c)
+					c.Unlock()
+				}
 				if !c.HostConfig.NetworkMode.IsContainer() && c.IsRunning() {
 					options, err := daemon.buildSandboxOptions(c)
 					if err != nil {
@@ -785,7 +796,7 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 		return nil, err
 	}
 
-	d.volumes, err = volumesservice.NewVolumeService(config.Root, d.PluginStore, rootIDs, d)
+	d.volumes, err = volumesservice.NewVolumeService(config.Root, d.PluginStore, idMappings, d, d)
 	if err != nil {
 		return nil, err
 	}
@@ -841,6 +852,9 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	// Check if Devices cgroup is mounted, it is hard requirement for container security,
 	// on Linux.
 	if runtime.GOOS == "linux" && !sysInfo.CgroupDevicesEnabled {
+		if sysInfo.CgroupUnified {
+			return nil, errors.New("Devices cgroup isn't supported with cgroup v2 by the runtime")
+		}
 		return nil, errors.New("Devices cgroup isn't mounted")
 	}
 
@@ -853,12 +867,30 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	d.execCommands = exec.NewStore()
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
+	d.gcStop = make(chan struct{})
+	if config.ContainerMetrics {
+		d.containerMetrics = newContainerMetrics(d.statsCollector, config.ContainerMetricsLabels)
+		metrics.Register(d.containerMetrics.ns)
+	}
 
 	d.EventsService = events.New()
+	if config.EventsJournalConfig.Enabled {
+		journal, err := events.NewJournal(filepath.Join(config.Root, "events"), int64(config.EventsJournalConfig.MaxSize), config.EventsJournalConfig.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		d.EventsService.SetJournal(journal)
+	}
+	for _, sink := range config.EventSinks.Value() {
+		if err := d.EventsService.AddSink(sink); err != nil {
+			return nil, errors.Wrapf(err, "error configuring %s event sink", sink.Type)
+		}
+	}
 	d.root = config.Root
 	d.idMappings = idMappings
 	d.seccompEnabled = sysInfo.Seccomp
 	d.apparmorEnabled = sysInfo.AppArmor
+	d.cgroupUnified = sysInfo.CgroupUnified
 
 	d.linkIndex = newLinkIndex()
 
@@ -980,6 +1012,9 @@ func (daemon *Daemon) ShutdownTimeout() int {
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	if daemon.gcStop != nil {
+		close(daemon.gcStop)
+	}
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -1028,6 +1063,9 @@ func (daemon *Daemon) Shutdown() error {
 	}
 
 	daemon.cleanupMetricsPlugins()
+	if daemon.containerMetrics != nil {
+		metrics.Deregister(daemon.containerMetrics.ns)
+	}
 
 	// Shutdown plugins after containers and layerstore. Don't change the order.
 	daemon.pluginShutdown()
@@ -1037,6 +1075,12 @@ func (daemon *Daemon) Shutdown() error {
 		daemon.netController.Stop()
 	}
 
+	if daemon.EventsService != nil {
+		if err := daemon.EventsService.Close(); err != nil {
+			logrus.Errorf("Error closing events journal: %v", err)
+		}
+	}
+
 	return daemon.cleanupMounts()
 }
 
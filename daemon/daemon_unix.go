@@ -25,6 +25,7 @@ import (
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/daemon/initlayer"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/cgroup2"
 	"github.com/docker/docker/pkg/containerfs"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/ioutils"
@@ -304,6 +305,15 @@ func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConf
 		hostConfig.IpcMode = containertypes.IpcMode(m)
 	}
 
+	// Set default cgroup namespace mode, if unset for container
+	if hostConfig.CgroupnsMode.IsEmpty() {
+		m := config.DefaultCgroupNamespaceMode
+		if daemon.configStore != nil && daemon.configStore.CgroupNamespaceMode != "" {
+			m = daemon.configStore.CgroupNamespaceMode
+		}
+		hostConfig.CgroupnsMode = containertypes.CgroupnsMode(m)
+	}
+
 	adaptSharedNamespaceContainer(daemon, hostConfig)
 
 	var err error
@@ -354,6 +364,10 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 	warnings := []string{}
 	fixMemorySwappiness(resources)
 
+	if sysInfo.CgroupUnified {
+		warnings = append(warnings, discardCgroup2UnsupportedResources(resources)...)
+	}
+
 	// memory subsystem checks and adjustments
 	if resources.Memory != 0 && resources.Memory < linuxMinMemory {
 		return warnings, fmt.Errorf("Minimum memory limit allowed is 4MB")
@@ -537,6 +551,39 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 	return warnings, nil
 }
 
+// discardCgroup2UnsupportedResources resets the resources which have no
+// equivalent in the cgroup v2 unified hierarchy, and returns a warning for
+// each of them.
+func discardCgroup2UnsupportedResources(resources *containertypes.Resources) []string {
+	var warnings []string
+	discard := func(option string) {
+		warning := fmt.Sprintf("%s is not supported with cgroup v2. %s discarded.", option, option)
+		warnings = append(warnings, warning)
+		logrus.Warn(warning)
+	}
+
+	if resources.KernelMemory > 0 {
+		discard("Kernel memory limit")
+		resources.KernelMemory = 0
+	}
+	if resources.MemorySwappiness != nil {
+		discard("Memory swappiness")
+		resources.MemorySwappiness = nil
+	}
+	if resources.OomKillDisable != nil {
+		if *resources.OomKillDisable {
+			discard("OomKillDisable")
+		}
+		resources.OomKillDisable = nil
+	}
+	if resources.CPURealtimePeriod > 0 || resources.CPURealtimeRuntime > 0 {
+		discard("CPU real-time scheduling")
+		resources.CPURealtimePeriod = 0
+		resources.CPURealtimeRuntime = 0
+	}
+	return warnings
+}
+
 func (daemon *Daemon) getCgroupDriver() string {
 	cgroupDriver := cgroupFsDriver
 
@@ -595,6 +642,13 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
 
+	if !hostConfig.CgroupnsMode.Valid() {
+		return warnings, fmt.Errorf("invalid cgroup namespace mode: %v", hostConfig.CgroupnsMode)
+	}
+	if hostConfig.CgroupnsMode.IsPrivate() && !sysInfo.CgroupNamespaces {
+		return warnings, fmt.Errorf("your kernel does not support cgroup namespaces")
+	}
+
 	// ip-forwarding does not affect container with '--net=host' (or '--net=none')
 	if sysInfo.IPv4ForwardingDisabled && !(hostConfig.NetworkMode.IsHost() || hostConfig.NetworkMode.IsNone()) {
 		warnings = append(warnings, "IPv4 forwarding is disabled. Networking will not work.")
@@ -1296,6 +1350,9 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 	if !c.IsRunning() {
 		return nil, errNotRunning(c.ID)
 	}
+	if daemon.cgroupUnified {
+		return daemon.cgroup2Stats(c)
+	}
 	cs, err := daemon.containerd.Stats(context.Background(), c.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "container not found") {
@@ -1396,9 +1453,144 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 		}
 	}
 
+	// The OOM kill counter isn't part of the metrics returned by containerd.
+	if oomKills, err := daemon.cgroupV1OOMKills(c); err == nil {
+		s.MemoryStats.OOMKills = oomKills
+	}
+
+	return s, nil
+}
+
+// oomControlPath is the path of the memory.oom_control file of the memory
+// cgroup of the process of a container.
+type oomControlPath struct {
+	pid  int
+	path string
+}
+
+// cgroupV1OOMKills reads the number of OOM kills in the memory cgroup of a
+// container from memory.oom_control. The counter is only available on kernels
+// 4.13 and newer. The path of the file is looked up once per container
+// process, and cached.
+func (daemon *Daemon) cgroupV1OOMKills(c *container.Container) (uint64, error) {
+	pid := c.GetPID()
+	if cached, ok := daemon.oomControlPaths.Load(c.ID); ok && cached.(oomControlPath).pid == pid {
+		return readOOMKills(cached.(oomControlPath).path)
+	}
+
+	cgroupPaths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return 0, err
+	}
+	mnt, err := cgroups.FindCgroupMountpoint("memory")
+	if err != nil {
+		return 0, err
+	}
+	path := filepath.Join(mnt, cgroupPaths["memory"], "memory.oom_control")
+	daemon.oomControlPaths.Store(c.ID, oomControlPath{pid: pid, path: path})
+	return readOOMKills(path)
+}
+
+func readOOMKills(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			return strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("oom_kill counter not supported by the kernel")
+}
+
+// cgroup2Stats reads the stats of a container from the files of its cgroup
+// in the cgroup v2 unified hierarchy.
+func (daemon *Daemon) cgroup2Stats(c *container.Container) (*types.StatsJSON, error) {
+	dir, err := cgroup2.PathForPid(c.GetPID())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNotRunning(c.ID)
+		}
+		return nil, err
+	}
+	stats, err := cgroup2.ReadStats(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &types.StatsJSON{}
+	s.Read = time.Now()
+
+	for _, e := range stats.IO {
+		s.BlkioStats.IoServiceBytesRecursive = append(s.BlkioStats.IoServiceBytesRecursive,
+			types.BlkioStatEntry{Major: e.Major, Minor: e.Minor, Op: "Read", Value: e.Rbytes},
+			types.BlkioStatEntry{Major: e.Major, Minor: e.Minor, Op: "Write", Value: e.Wbytes},
+		)
+		s.BlkioStats.IoServicedRecursive = append(s.BlkioStats.IoServicedRecursive,
+			types.BlkioStatEntry{Major: e.Major, Minor: e.Minor, Op: "Read", Value: e.Rios},
+			types.BlkioStatEntry{Major: e.Major, Minor: e.Minor, Op: "Write", Value: e.Wios},
+		)
+	}
+
+	// cgroup v2 reports times in microseconds, and doesn't report the
+	// per-CPU usage.
+	s.CPUStats = types.CPUStats{
+		CPUUsage: types.CPUUsage{
+			TotalUsage:        stats.CPU.UsageUsec * 1000,
+			UsageInKernelmode: stats.CPU.SystemUsec * 1000,
+			UsageInUsermode:   stats.CPU.UserUsec * 1000,
+		},
+		ThrottlingData: types.ThrottlingData{
+			Periods:          stats.CPU.NrPeriods,
+			ThrottledPeriods: stats.CPU.NrThrottled,
+			ThrottledTime:    stats.CPU.ThrottledUsec * 1000,
+		},
+	}
+
+	s.MemoryStats = types.MemoryStats{
+		Stats: stats.Memory.Stats,
+		Usage: stats.Memory.Usage,
+		Limit: stats.Memory.Limit,
+	}
+	// if the container does not set memory limit, use the machineMemory
+	if (s.MemoryStats.Limit == 0 || s.MemoryStats.Limit > daemon.machineMemory) && daemon.machineMemory > 0 {
+		s.MemoryStats.Limit = daemon.machineMemory
+	}
+
+	s.PidsStats = types.PidsStats{
+		Current: stats.Pids.Current,
+		Limit:   stats.Pids.Limit,
+	}
+
+	s.MemoryStats.OOMKills = stats.Memory.Events["oom_kill"]
+	s.CPUStats.Pressure = pressureStats(stats.CPUPressure)
+	s.MemoryStats.Pressure = pressureStats(stats.MemoryPressure)
+	s.BlkioStats.Pressure = pressureStats(stats.IOPressure)
+
 	return s, nil
 }
 
+func pressureStats(p *cgroup2.Pressure) *types.PressureStats {
+	if p == nil {
+		return nil
+	}
+	convert := func(d *cgroup2.PressureData) *types.PressureData {
+		if d == nil {
+			return nil
+		}
+		return &types.PressureData{Avg10: d.Avg10, Avg60: d.Avg60, Avg300: d.Avg300, Total: d.Total}
+	}
+	return &types.PressureStats{Some: convert(p.Some), Full: convert(p.Full)}
+}
+
 // setDefaultIsolation determines the default isolation mode for the
 // daemon to run in. This is only applicable on Windows
 func (daemon *Daemon) setDefaultIsolation() error {
@@ -8,9 +8,13 @@ import (
 	"os"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/pkg/cgroup2"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 )
 
 type fakeContainerGetter struct {
@@ -266,3 +270,37 @@ func TestNetworkOptions(t *testing.T) {
 		t.Fatal("Expected networkOptions error, got nil")
 	}
 }
+
+func TestDiscardCgroup2UnsupportedResources(t *testing.T) {
+	swappiness := int64(60)
+	oomKillDisable := true
+	resources := containertypes.Resources{
+		Memory:             64 * 1024 * 1024,
+		KernelMemory:       32 * 1024 * 1024,
+		MemorySwappiness:   &swappiness,
+		OomKillDisable:     &oomKillDisable,
+		CPURealtimeRuntime: 1000,
+	}
+
+	warnings := discardCgroup2UnsupportedResources(&resources)
+	if len(warnings) != 4 {
+		t.Fatalf("expected 4 warnings, got %v", warnings)
+	}
+	if resources.KernelMemory != 0 || resources.MemorySwappiness != nil || resources.OomKillDisable != nil || resources.CPURealtimeRuntime != 0 {
+		t.Fatalf("unsupported resources were not discarded: %+v", resources)
+	}
+	if resources.Memory != 64*1024*1024 {
+		t.Fatalf("expected memory limit to be kept, got %d", resources.Memory)
+	}
+}
+
+func TestPressureStats(t *testing.T) {
+	assert.Check(t, is.Nil(pressureStats(nil)))
+
+	p := &cgroup2.Pressure{
+		Some: &cgroup2.PressureData{Avg10: 1.5, Avg60: 0.5, Avg300: 0.1, Total: 1000},
+	}
+	assert.Check(t, is.DeepEqual(&types.PressureStats{
+		Some: &types.PressureData{Avg10: 1.5, Avg60: 0.5, Avg300: 0.1, Total: 1000},
+	}, pressureStats(p)))
+}
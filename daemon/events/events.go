@@ -5,7 +5,9 @@ import (
 	"time"
 
 	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/pubsub"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -15,9 +17,11 @@ const (
 
 // Events is pubsub channel for events generated by the engine.
 type Events struct {
-	mu     sync.Mutex
-	events []eventtypes.Message
-	pub    *pubsub.Publisher
+	mu      sync.Mutex
+	events  []eventtypes.Message
+	pub     *pubsub.Publisher
+	journal *Journal
+	sinks   []*sinkForwarder
 }
 
 // New returns new *Events instance
@@ -28,6 +32,51 @@ func New() *Events {
 	}
 }
 
+// SetJournal persists all further events to j, and uses it to load the past
+// events for subscribers that are older than the memory buffer.
+func (e *Events) SetJournal(j *Journal) {
+	e.mu.Lock()
+	e.journal = j
+	e.mu.Unlock()
+}
+
+// AddSink forwards all further events matching the filters of cfg to the
+// sink it describes.
+func (e *Events) AddSink(cfg opts.EventSinkConfig) error {
+	f, err := newSinkForwarder(cfg)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.sinks = append(e.sinks, f)
+	e.mu.Unlock()
+
+	go f.run()
+	return nil
+}
+
+// Close stops forwarding events to sinks and closes the event journal, if any.
+func (e *Events) Close() error {
+	e.mu.Lock()
+	sinks := e.sinks
+	e.sinks = nil
+	journal := e.journal
+	e.journal = nil
+	e.mu.Unlock()
+
+	// Stopping a forwarder waits for the write in progress, which must not
+	// block the publication of events.
+	for _, f := range sinks {
+		f.close()
+	}
+
+	if journal == nil {
+		return nil
+	}
+	return journal.Close()
+}
+
 // Subscribe adds new listener to events, returns slice of 256 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
@@ -51,14 +100,21 @@ func (e *Events) Subscribe() ([]eventtypes.Message, chan interface{}, func()) {
 // of interface{}, so you need type assertion).
 func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtypes.Message, chan interface{}) {
 	eventSubscribers.Inc()
-	e.mu.Lock()
 
 	var topic func(m interface{}) bool
 	if ef != nil && ef.filter.Len() > 0 {
 		topic = func(m interface{}) bool { return ef.Include(m.(eventtypes.Message)) }
 	}
 
+	e.mu.Lock()
 	buffered := e.loadBufferedEvents(since, until, topic)
+	journal := e.journal
+	// Events older than cutoff are read from the journal, the others are
+	// either in the memory buffer or will be sent on the channel.
+	cutoff := time.Now().UnixNano()
+	if len(e.events) > 0 {
+		cutoff = e.events[0].TimeNano
+	}
 
 	var ch chan interface{}
 	if topic != nil {
@@ -67,8 +123,13 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 		// Subscribe to all events if there are no filters
 		ch = e.pub.Subscribe()
 	}
-
 	e.mu.Unlock()
+
+	// The journal is read without holding the lock, so that reading it does
+	// not block the publication of events.
+	if journal != nil {
+		buffered = append(loadJournalEvents(journal, cutoff, since, until, topic), buffered...)
+	}
 	return buffered, ch
 }
 
@@ -117,6 +178,16 @@ func (e *Events) PublishMessage(jm eventtypes.Message) {
 	} else {
 		e.events = append(e.events, jm)
 	}
+	if e.journal != nil {
+		// This only queues the event, it is written by the journal's
+		// writer goroutine.
+		if err := e.journal.Write(jm); err != nil {
+			logrus.WithError(err).Error("failed to write event to the events journal")
+		}
+	}
+	for _, f := range e.sinks {
+		f.enqueue(jm)
+	}
 	e.mu.Unlock()
 	e.pub.Publish(jm)
 }
@@ -126,8 +197,8 @@ func (e *Events) SubscribersCount() int {
 	return e.pub.Len()
 }
 
-// loadBufferedEvents iterates over the cached events in the buffer
-// and returns those that were emitted between two specific dates.
+// loadBufferedEvents iterates over the cached events in the buffer and returns those
+// that were emitted between two specific dates.
 // It uses `time.Unix(seconds, nanoseconds)` to generate valid dates with those arguments.
 // It filters those buffered messages with a topic function if it's not nil, otherwise it adds all messages.
 func (e *Events) loadBufferedEvents(since, until time.Time, topic func(interface{}) bool) []eventtypes.Message {
@@ -163,3 +234,33 @@ func (e *Events) loadBufferedEvents(since, until time.Time, topic func(interface
 	}
 	return buffered
 }
+
+// loadJournalEvents returns the events of the journal that were emitted
+// between two specific dates and before cutoff, the time of the oldest event
+// in the memory buffer: the buffer has the most recent events, including the
+// ones that may not have been written to the journal yet.
+func loadJournalEvents(j *Journal, cutoff int64, since, until time.Time, topic func(interface{}) bool) []eventtypes.Message {
+	if since.IsZero() && until.IsZero() {
+		return nil
+	}
+
+	var sinceNanoUnix int64
+	if !since.IsZero() {
+		sinceNanoUnix = since.UnixNano()
+	}
+
+	untilNanoUnix := cutoff - 1
+	if !until.IsZero() && until.UnixNano() < untilNanoUnix {
+		untilNanoUnix = until.UnixNano()
+	}
+	if sinceNanoUnix > untilNanoUnix {
+		return nil
+	}
+
+	events, err := j.Read(sinceNanoUnix, untilNanoUnix, topic)
+	if err != nil {
+		logrus.WithError(err).Error("failed to read events from the events journal")
+		return nil
+	}
+	return events
+}
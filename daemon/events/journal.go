@@ -0,0 +1,260 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	journalFileName = "events.log"
+	// journalQueueSize is the number of events that can be waiting to be
+	// written to the journal before new events are dropped.
+	journalQueueSize = 1024
+)
+
+// Journal persists events to disk so that they can be replayed to
+// subscribers asking for events that are no longer in the memory buffer,
+// including events emitted before the daemon was restarted.
+//
+// Events are stored as JSON, one per line. When the current file reaches
+// maxSize, it is rotated and the oldest file is removed so that at most
+// maxFiles files are kept.
+//
+// Events are written by a separate goroutine, so that writing an event does
+// not wait for the disk.
+type Journal struct {
+	mu       sync.Mutex // protects the files
+	dir      string
+	f        *os.File
+	size     int64
+	maxSize  int64
+	maxFiles int
+
+	qmu    sync.Mutex // protects queue and closed
+	queue  chan journalEntry
+	closed bool
+	done   chan struct{}
+}
+
+// journalEntry is an event waiting to be written to the journal, or, if
+// flushed is set, a request to be notified once the previous events have been
+// written.
+type journalEntry struct {
+	msg     eventtypes.Message
+	flushed chan struct{}
+}
+
+// NewJournal opens the event journal stored in dir, creating it if needed.
+func NewJournal(dir string, maxSize int64, maxFiles int) (*Journal, error) {
+	if maxSize <= 0 {
+		return nil, errors.Errorf("invalid events journal max size: %d", maxSize)
+	}
+	if maxFiles < 1 {
+		return nil, errors.Errorf("invalid events journal max files: %d", maxFiles)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "error creating events journal directory")
+	}
+
+	j := &Journal{
+		dir:      dir,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+		queue:    make(chan journalEntry, journalQueueSize),
+		done:     make(chan struct{}),
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	go j.run()
+	return j, nil
+}
+
+func (j *Journal) path(n int) string {
+	if n == 0 {
+		return filepath.Join(j.dir, journalFileName)
+	}
+	return filepath.Join(j.dir, journalFileName+"."+strconv.Itoa(n))
+}
+
+func (j *Journal) open() error {
+	f, err := os.OpenFile(j.path(0), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrap(err, "error opening events journal")
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "error opening events journal")
+	}
+	j.f = f
+	j.size = fi.Size()
+	return nil
+}
+
+// Write queues an event to be appended to the journal. It never blocks: an
+// error is returned and the event is dropped if too many events are already
+// waiting to be written.
+func (j *Journal) Write(m eventtypes.Message) error {
+	j.qmu.Lock()
+	defer j.qmu.Unlock()
+
+	if j.closed {
+		return errors.New("events journal is closed")
+	}
+	select {
+	case j.queue <- journalEntry{msg: m}:
+		return nil
+	default:
+		return errors.New("events journal queue is full")
+	}
+}
+
+// flush waits until the events queued before it was called are written.
+func (j *Journal) flush() {
+	flushed := make(chan struct{})
+	j.qmu.Lock()
+	if j.closed {
+		j.qmu.Unlock()
+		return
+	}
+	j.queue <- journalEntry{flushed: flushed}
+	j.qmu.Unlock()
+	<-flushed
+}
+
+// run writes the queued events until the journal is closed.
+func (j *Journal) run() {
+	defer close(j.done)
+	for entry := range j.queue {
+		if entry.flushed != nil {
+			close(entry.flushed)
+			continue
+		}
+		if err := j.write(entry.msg); err != nil {
+			logrus.WithError(err).Error("failed to write event to the events journal")
+		}
+	}
+}
+
+func (j *Journal) write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return errors.New("events journal is closed")
+	}
+	if j.size > 0 && j.size+int64(len(b)) > j.maxSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := j.f.Write(b)
+	j.size += int64(n)
+	return err
+}
+
+// rotate shifts the journal files, removing the oldest one, and starts a new
+// current file. It must be called with j.mu held.
+func (j *Journal) rotate() error {
+	if err := j.f.Close(); err != nil {
+		return errors.Wrap(err, "error closing events journal")
+	}
+	j.f = nil
+
+	if j.maxFiles == 1 {
+		if err := os.Remove(j.path(0)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "error removing events journal")
+		}
+		return j.open()
+	}
+
+	if err := os.Remove(j.path(j.maxFiles - 1)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "error removing oldest events journal file")
+	}
+	for i := j.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(j.path(i-1), j.path(i)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "error rotating events journal")
+		}
+	}
+	return j.open()
+}
+
+// Read returns the events from the journal that were emitted between since
+// and until (in nanoseconds since the epoch, 0 meaning unbounded), oldest
+// first. Events are filtered with topic if it's not nil.
+func (j *Journal) Read(since, until int64, topic func(interface{}) bool) ([]eventtypes.Message, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var events []eventtypes.Message
+	for i := j.maxFiles - 1; i >= 0; i-- {
+		f, err := os.Open(j.path(i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "error opening events journal")
+		}
+
+		s := bufio.NewScanner(f)
+		s.Buffer(nil, 1024*1024)
+		for s.Scan() {
+			var ev eventtypes.Message
+			if err := json.Unmarshal(s.Bytes(), &ev); err != nil {
+				// The daemon may have been killed while writing the last
+				// event; skip what cannot be decoded.
+				logrus.WithError(err).WithField("file", f.Name()).Debug("skipping corrupted entry in events journal")
+				continue
+			}
+			if ev.TimeNano < since || (until > 0 && ev.TimeNano > until) {
+				continue
+			}
+			if topic == nil || topic(ev) {
+				events = append(events, ev)
+			}
+		}
+		err = s.Err()
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading events journal")
+		}
+	}
+	return events, nil
+}
+
+// Close writes the queued events and closes the journal. Events can no longer
+// be written after it is closed.
+func (j *Journal) Close() error {
+	j.qmu.Lock()
+	if !j.closed {
+		j.closed = true
+		close(j.queue)
+	}
+	j.qmu.Unlock()
+	<-j.done
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return nil
+	}
+	err := j.f.Close()
+	j.f = nil
+	return err
+}
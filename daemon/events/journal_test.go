@@ -0,0 +1,172 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func newTestMessage(id string, ts time.Time) events.Message {
+	return events.Message{
+		Type:     events.ContainerEventType,
+		Action:   "die",
+		Actor:    events.Actor{ID: id},
+		Scope:    "local",
+		Time:     ts.Unix(),
+		TimeNano: ts.UnixNano(),
+	}
+}
+
+func TestJournalReplayAfterRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	j, err := NewJournal(dir, 1024*1024, 2)
+	assert.NilError(t, err)
+
+	e := New()
+	e.SetJournal(j)
+	start := time.Now()
+	for _, id := range []string{"a", "b", "c"} {
+		e.Log("die", events.ContainerEventType, events.Actor{ID: id})
+	}
+	assert.NilError(t, e.Close())
+
+	// A new Events instance has nothing in memory, but replays the journal.
+	j, err = NewJournal(dir, 1024*1024, 2)
+	assert.NilError(t, err)
+	e = New()
+	e.SetJournal(j)
+	defer e.Close()
+
+	buffered, l := e.SubscribeTopic(start, time.Time{}, nil)
+	defer e.Evict(l)
+	assert.Assert(t, is.Len(buffered, 3))
+	assert.Check(t, is.Equal("a", buffered[0].Actor.ID))
+	assert.Check(t, is.Equal("c", buffered[2].Actor.ID))
+
+	f := filters.NewArgs(filters.Arg("container", "b"))
+	buffered, l2 := e.SubscribeTopic(start, time.Time{}, NewFilter(f))
+	defer e.Evict(l2)
+	assert.Assert(t, is.Len(buffered, 1))
+	assert.Check(t, is.Equal("b", buffered[0].Actor.ID))
+}
+
+func TestJournalRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Each file only has room for a couple of events.
+	j, err := NewJournal(dir, 300, 3)
+	assert.NilError(t, err)
+	defer j.Close()
+
+	start := time.Unix(1000, 0)
+	for i := 0; i < 20; i++ {
+		assert.NilError(t, j.Write(newTestMessage(strconv.Itoa(i), start.Add(time.Duration(i)*time.Second))))
+	}
+	j.flush()
+
+	files, err := filepath.Glob(filepath.Join(dir, journalFileName+"*"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(files, 3))
+
+	all, err := j.Read(0, 0, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, len(all) > 0 && len(all) < 20)
+	// Oldest events are dropped, the most recent ones are kept in order.
+	assert.Check(t, is.Equal("19", all[len(all)-1].Actor.ID))
+	for i := 1; i < len(all); i++ {
+		assert.Check(t, all[i-1].TimeNano < all[i].TimeNano)
+	}
+
+	until := start.Add(18 * time.Second).UnixNano()
+	since := start.Add(17 * time.Second).UnixNano()
+	ranged, err := j.Read(since, until, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ranged, 2))
+	assert.Check(t, is.Equal("17", ranged[0].Actor.ID))
+}
+
+func TestJournalSkipsCorruptedEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	j, err := NewJournal(dir, 1024*1024, 1)
+	assert.NilError(t, err)
+	defer j.Close()
+
+	assert.NilError(t, j.Write(newTestMessage("a", time.Unix(1, 0))))
+	j.flush()
+	_, err = j.f.Write([]byte(`{"Type":"contai` + "\n"))
+	assert.NilError(t, err)
+	assert.NilError(t, j.Write(newTestMessage("b", time.Unix(2, 0))))
+	j.flush()
+
+	all, err := j.Read(0, 0, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(all, 2))
+	assert.Check(t, is.Equal("b", all[1].Actor.ID))
+}
+
+func TestJournalMergedWithMemoryBuffer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	j, err := NewJournal(dir, 1024*1024, 1)
+	assert.NilError(t, err)
+
+	// "a" was emitted before the daemon restarted and is only in the journal,
+	// "b" and "c" are in memory and may not have been written yet.
+	start := time.Unix(1000, 0)
+	assert.NilError(t, j.Write(newTestMessage("a", start)))
+	j.flush()
+
+	e := New()
+	e.SetJournal(j)
+	defer e.Close()
+	e.PublishMessage(newTestMessage("b", start.Add(time.Second)))
+	e.PublishMessage(newTestMessage("c", start.Add(2*time.Second)))
+
+	buffered, l := e.SubscribeTopic(start, time.Time{}, nil)
+	defer e.Evict(l)
+	assert.Assert(t, is.Len(buffered, 3))
+	for i, id := range []string{"a", "b", "c"} {
+		assert.Check(t, is.Equal(id, buffered[i].Actor.ID))
+	}
+
+	buffered, l2 := e.SubscribeTopic(start, start.Add(time.Second), nil)
+	defer e.Evict(l2)
+	assert.Assert(t, is.Len(buffered, 2))
+	assert.Check(t, is.Equal("b", buffered[1].Actor.ID))
+}
+
+func TestJournalWriteAfterClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-journal")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	j, err := NewJournal(dir, 1024*1024, 1)
+	assert.NilError(t, err)
+	assert.NilError(t, j.Write(newTestMessage("a", time.Unix(1, 0))))
+	assert.NilError(t, j.Close())
+	assert.Check(t, is.ErrorContains(j.Write(newTestMessage("b", time.Unix(2, 0))), "closed"))
+
+	// Queued events are written when the journal is closed.
+	all, err := j.Read(0, 0, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(all, 1))
+	assert.Check(t, is.Equal("a", all[0].Actor.ID))
+}
@@ -0,0 +1,236 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSinkMaxRetries    = 5
+	sinkQueueSize            = 1024
+	sinkInitialRetryDelay    = 100 * time.Millisecond
+	sinkMaxRetryDelay        = 10 * time.Second
+	sinkWriteTimeout         = 10 * time.Second
+	webhookSinkContentType   = "application/json"
+	webhookSinkMaxErrBodyLen = 512
+)
+
+// Sink receives the events forwarded from the daemon event stream.
+type Sink interface {
+	// Write sends an event to the sink. Failed writes are retried.
+	Write(eventtypes.Message) error
+	// Close releases the resources held by the sink.
+	Close() error
+}
+
+// NewSink returns the sink described by cfg.
+func NewSink(cfg opts.EventSinkConfig) (Sink, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case opts.EventSinkWebhook:
+		return &webhookSink{
+			url:    cfg.Address,
+			client: &http.Client{Timeout: sinkWriteTimeout},
+		}, nil
+	case opts.EventSinkUnix:
+		return &unixSink{path: cfg.Address}, nil
+	default:
+		f, err := os.OpenFile(cfg.Address, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, errors.Wrap(err, "error opening event sink file")
+		}
+		return &fileSink{f: f}, nil
+	}
+}
+
+// webhookSink POSTs each event as a JSON document to a URL.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookSink) Write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, webhookSinkContentType, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body := make([]byte, webhookSinkMaxErrBodyLen)
+		n, _ := resp.Body.Read(body)
+		return errors.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body[:n])))
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	return nil
+}
+
+// unixSink writes each event as a line of JSON to a unix socket. The
+// connection is re-established when a write fails.
+type unixSink struct {
+	path string
+	conn net.Conn
+}
+
+func (s *unixSink) Write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if s.conn == nil {
+		if s.conn, err = net.DialTimeout("unix", s.path, sinkWriteTimeout); err != nil {
+			return err
+		}
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(sinkWriteTimeout))
+	if _, err := s.conn.Write(append(b, '\n')); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *unixSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// fileSink appends each event as a line of JSON to a file.
+type fileSink struct {
+	f *os.File
+}
+
+func (s *fileSink) Write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = s.f.Write(append(b, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.f.Close()
+}
+
+// sinkForwarder forwards the events queued for a sink, retrying failed
+// writes with an exponential backoff. Each sink has its own queue so that a
+// slow sink neither delays the other subscribers nor misses events because
+// of them; events are dropped when the queue of the sink is full.
+type sinkForwarder struct {
+	name       string
+	sink       Sink
+	filter     *Filter
+	maxRetries int
+	queue      chan eventtypes.Message
+	stop       chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+}
+
+func newSinkForwarder(cfg opts.EventSinkConfig) (*sinkForwarder, error) {
+	args, err := cfg.FilterArgs()
+	if err != nil {
+		return nil, err
+	}
+	sink, err := NewSink(cfg)
+	if err != nil {
+		return nil, err
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultSinkMaxRetries
+	}
+
+	f := &sinkForwarder{
+		name:       cfg.Type + " " + cfg.Address,
+		sink:       sink,
+		maxRetries: maxRetries,
+		queue:      make(chan eventtypes.Message, sinkQueueSize),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	if args.Len() > 0 {
+		f.filter = NewFilter(args)
+	}
+	return f, nil
+}
+
+// enqueue queues m to be forwarded if it matches the filters of the sink.
+// It never blocks.
+func (f *sinkForwarder) enqueue(m eventtypes.Message) {
+	if f.filter != nil && !f.filter.Include(m) {
+		return
+	}
+	select {
+	case f.queue <- m:
+	default:
+		logrus.WithField("sink", f.name).Errorf("dropping event %s %s: the sink is not keeping up", m.Type, m.Action)
+	}
+}
+
+func (f *sinkForwarder) run() {
+	defer close(f.done)
+	defer f.sink.Close()
+
+	for {
+		select {
+		case <-f.stop:
+			return
+		case m := <-f.queue:
+			f.forward(m)
+		}
+	}
+}
+
+func (f *sinkForwarder) forward(m eventtypes.Message) {
+	delay := sinkInitialRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := f.sink.Write(m)
+		if err == nil {
+			return
+		}
+		if attempt >= f.maxRetries {
+			logrus.WithError(err).WithField("sink", f.name).Errorf("dropping event %s %s after %d retries", m.Type, m.Action, attempt)
+			return
+		}
+		logrus.WithError(err).WithField("sink", f.name).Debug("failed to forward event, retrying")
+
+		select {
+		case <-f.stop:
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > sinkMaxRetryDelay {
+			delay = sinkMaxRetryDelay
+		}
+	}
+}
+
+func (f *sinkForwarder) close() {
+	f.stopOnce.Do(func() { close(f.stop) })
+	<-f.done
+}
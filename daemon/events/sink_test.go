@@ -0,0 +1,149 @@
+package events // import "github.com/docker/docker/daemon/events"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/opts"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/poll"
+)
+
+func TestWebhookSinkRetries(t *testing.T) {
+	var requests int32
+	received := make(chan events.Message, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		var m events.Message
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- m
+	}))
+	defer srv.Close()
+
+	e := New()
+	defer e.Close()
+	assert.NilError(t, e.AddSink(opts.EventSinkConfig{Type: opts.EventSinkWebhook, Address: srv.URL, Filters: []string{"container=foo"}}))
+
+	e.Log("start", events.ContainerEventType, events.Actor{ID: "bar"})
+	e.Log("die", events.ContainerEventType, events.Actor{ID: "foo"})
+
+	select {
+	case m := <-received:
+		assert.Check(t, is.Equal("foo", m.Actor.ID))
+		assert.Check(t, is.Equal("die", m.Action))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event on webhook")
+	}
+	assert.Check(t, is.Equal(int32(2), atomic.LoadInt32(&requests)))
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-sink")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	e := New()
+	assert.NilError(t, e.AddSink(opts.EventSinkConfig{Type: opts.EventSinkFile, Address: path}))
+	e.Log("create", events.ContainerEventType, events.Actor{ID: "foo"})
+	e.Log("pull", events.ImageEventType, events.Actor{ID: "busybox"})
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return poll.Error(err)
+		}
+		if n := len(splitLines(b)); n != 2 {
+			return poll.Continue("got %d events", n)
+		}
+		return poll.Success()
+	})
+	assert.NilError(t, e.Close())
+
+	b, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+	var m events.Message
+	assert.NilError(t, json.Unmarshal(splitLines(b)[1], &m))
+	assert.Check(t, is.Equal("busybox", m.Actor.ID))
+}
+
+func TestUnixSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events-sink")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.sock")
+
+	l, err := net.Listen("unix", path)
+	assert.NilError(t, err)
+	defer l.Close()
+
+	e := New()
+	defer e.Close()
+	assert.NilError(t, e.AddSink(opts.EventSinkConfig{Type: opts.EventSinkUnix, Address: path}))
+	e.Log("destroy", events.ContainerEventType, events.Actor{ID: "foo"})
+
+	conn, err := l.Accept()
+	assert.NilError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var m events.Message
+	assert.NilError(t, json.NewDecoder(bufio.NewReader(conn)).Decode(&m))
+	assert.Check(t, is.Equal("destroy", m.Action))
+}
+
+func TestSlowSinkDoesNotBlockPublish(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	e := New()
+	assert.NilError(t, e.AddSink(opts.EventSinkConfig{Type: opts.EventSinkWebhook, Address: srv.URL}))
+
+	// Events beyond the queue of the sink are dropped instead of waiting
+	// for the webhook.
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*sinkQueueSize; i++ {
+			e.Log("start", events.ContainerEventType, events.Actor{ID: "foo"})
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing events is blocked by the sink")
+	}
+
+	close(release)
+	assert.NilError(t, e.Close())
+}
+
+func splitLines(b []byte) [][]byte {
+	var lines [][]byte
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		lines = append(lines, append([]byte(nil), s.Bytes()...))
+	}
+	return lines
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
+	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/container/stream"
@@ -99,6 +103,10 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 		return "", err
 	}
 
+	if config.Timeout < 0 {
+		return "", errdefs.InvalidParameter(errors.New("exec timeout cannot be negative"))
+	}
+
 	cmd := strslice.StrSlice(config.Cmd)
 	entrypoint, args := d.getEntrypointAndArgs(strslice.StrSlice{}, cmd)
 
@@ -123,6 +131,7 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 	execConfig.Privileged = config.Privileged
 	execConfig.User = config.User
 	execConfig.WorkingDir = config.WorkingDir
+	execConfig.Timeout = config.Timeout
 
 	linkedEnv, err := d.setupLinkedContainers(cntr)
 	if err != nil {
@@ -185,8 +194,7 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 		if err != nil {
 			ec.Lock()
 			ec.Running = false
-			exitCode := 126
-			ec.ExitCode = &exitCode
+			ec.SetExitCode(126)
 			if err := ec.CloseStreams(); err != nil {
 				logrus.Errorf("failed to cleanup exec %s streams: %s", c.ID, err)
 			}
@@ -261,6 +269,10 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 	c.ExecCommands.Unlock()
 	ec.Unlock()
 
+	if ec.Timeout > 0 {
+		go d.execTimeout(c, ec)
+	}
+
 	select {
 	case <-ctx.Done():
 		logrus.Debugf("Sending TERM signal to process %v in container %v", name, c.ID)
@@ -287,6 +299,91 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 	return nil
 }
 
+// execTimeout terminates the exec process if it is still running after the
+// timeout of the exec.
+func (d *Daemon) execTimeout(c *container.Container, ec *exec.Config) {
+	timer := time.NewTimer(ec.Timeout)
+	defer timer.Stop()
+	select {
+	case <-ec.Exited():
+		return
+	case <-timer.C:
+	}
+
+	logrus.Infof("Container %v, process %v did not exit within its %s timeout - sending TERM", c.ID, ec.ID, ec.Timeout)
+	d.containerd.SignalProcess(context.Background(), c.ID, ec.ID, int(signal.SignalMap["TERM"]))
+	select {
+	case <-time.After(termProcessTimeout * time.Second):
+		logrus.Infof("Container %v, process %v failed to exit within %d seconds of signal TERM - using the force", c.ID, ec.ID, termProcessTimeout)
+		d.containerd.SignalProcess(context.Background(), c.ID, ec.ID, int(signal.SignalMap["KILL"]))
+	case <-ec.Exited():
+	}
+}
+
+// ContainerExecKill sends a signal to a running exec process. It sends
+// SIGKILL if sig is 0.
+func (d *Daemon) ContainerExecKill(name string, sig uint64) error {
+	ec, err := d.getExecConfig(name)
+	if err != nil {
+		return err
+	}
+	if sig == 0 {
+		sig = uint64(signal.SignalMap["KILL"])
+	}
+	if !signal.ValidSignalForPlatform(syscall.Signal(sig)) {
+		return errdefs.InvalidParameter(fmt.Errorf("The %s daemon does not support signal %d", runtime.GOOS, sig))
+	}
+
+	ec.Lock()
+	running := ec.Running && ec.Pid != 0
+	ec.Unlock()
+	if !running {
+		return errdefs.Conflict(fmt.Errorf("Exec command %s is not running", ec.ID))
+	}
+
+	return d.containerd.SignalProcess(context.Background(), ec.ContainerID, ec.ID, int(sig))
+}
+
+// ContainerExecWait waits for an exec process to exit, and returns its exit
+// code. The exit code is nil if the exec was removed along with its
+// container before exiting.
+func (d *Daemon) ContainerExecWait(ctx context.Context, name string) (*int, error) {
+	ec := d.execCommands.Get(name)
+	if ec == nil {
+		return nil, errExecNotFound(name)
+	}
+
+	select {
+	case <-ec.Exited():
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	ec.Lock()
+	defer ec.Unlock()
+	return ec.ExitCode, nil
+}
+
+// ContainerExecList returns the exec instances of a container, including
+// the instances which have exited and are not cleaned up yet.
+func (d *Daemon) ContainerExecList(name string) ([]*backend.ExecInspect, error) {
+	c, err := d.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	execs := []*backend.ExecInspect{}
+	for _, e := range d.execCommands.Commands() {
+		if e.ContainerID == c.ID {
+			execs = append(execs, execInspect(e))
+		}
+	}
+	sort.Slice(execs, func(i, j int) bool {
+		return execs[i].ID < execs[j].ID
+	})
+	return execs, nil
+}
+
 // execCommandGC runs a ticker to clean up the daemon references
 // of exec configs that are no longer part of the container.
 func (d *Daemon) execCommandGC() {
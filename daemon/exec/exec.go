@@ -3,6 +3,7 @@ package exec // import "github.com/docker/docker/daemon/exec"
 import (
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/containerd/containerd/cio"
 	"github.com/docker/docker/container/stream"
@@ -34,6 +35,11 @@ type Config struct {
 	WorkingDir   string
 	Env          []string
 	Pid          int
+	Timeout      time.Duration
+
+	// exited is closed once the exec has exited, or has been removed
+	// along with its container.
+	exited chan struct{}
 }
 
 // NewConfig initializes the a new exec configuration
@@ -42,6 +48,7 @@ func NewConfig() *Config {
 		ID:           stringid.GenerateNonCryptoID(),
 		StreamConfig: stream.NewConfig(),
 		Started:      make(chan struct{}),
+		exited:       make(chan struct{}),
 	}
 }
 
@@ -86,6 +93,26 @@ func (c *Config) CloseStreams() error {
 // SetExitCode sets the exec config's exit code
 func (c *Config) SetExitCode(code int) {
 	c.ExitCode = &code
+	c.SetExited()
+}
+
+// SetExited notifies the processes waiting for the exec to exit. It is
+// called when the exec exits, or without exit code when the exec is removed
+// along with its container.
+func (c *Config) SetExited() {
+	if c.exited == nil {
+		return
+	}
+	select {
+	case <-c.exited:
+	default:
+		close(c.exited)
+	}
+}
+
+// Exited returns a channel which is closed once the exec has exited.
+func (c *Config) Exited() <-chan struct{} {
+	return c.exited
 }
 
 // Store keeps track of the exec configurations.
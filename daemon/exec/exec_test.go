@@ -0,0 +1,27 @@
+package exec // import "github.com/docker/docker/daemon/exec"
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestSetExitCode(t *testing.T) {
+	c := NewConfig()
+	select {
+	case <-c.Exited():
+		t.Fatal("exec should not be exited")
+	default:
+	}
+
+	c.SetExitCode(2)
+	<-c.Exited()
+	assert.Assert(t, c.ExitCode != nil)
+	assert.Check(t, is.Equal(2, *c.ExitCode))
+
+	// Setting the exit code again must not panic.
+	c.SetExitCode(3)
+	c.SetExited()
+	assert.Check(t, is.Equal(3, *c.ExitCode))
+}
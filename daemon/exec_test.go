@@ -0,0 +1,154 @@
+// +build linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/errdefs"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type execSignalMockContainerdClient struct {
+	MockContainerdClient
+	mu      sync.Mutex
+	signals []int
+	// onSignal is called for each signal sent.
+	onSignal func(signal int)
+}
+
+func (c *execSignalMockContainerdClient) SignalProcess(ctx context.Context, containerID, processID string, signal int) error {
+	c.mu.Lock()
+	c.signals = append(c.signals, signal)
+	c.mu.Unlock()
+	if c.onSignal != nil {
+		c.onSignal(signal)
+	}
+	return nil
+}
+
+func newExecTestDaemon(mc *execSignalMockContainerdClient) (*Daemon, *container.Container) {
+	d := &Daemon{
+		execCommands: exec.NewStore(),
+		containerd:   mc,
+		containers:   container.NewMemoryStore(),
+	}
+	c := &container.Container{
+		ID:           "container_id",
+		ExecCommands: exec.NewStore(),
+		State:        &container.State{Running: true},
+	}
+	d.containers.Add(c.ID, c)
+	return d, c
+}
+
+func TestExecKill(t *testing.T) {
+	mc := &execSignalMockContainerdClient{}
+	d, c := newExecTestDaemon(mc)
+	ec := exec.NewConfig()
+	ec.ContainerID = c.ID
+	d.registerExecCommand(c, ec)
+
+	err := d.ContainerExecKill(ec.ID, 0)
+	assert.Check(t, errdefs.IsConflict(err), "expected a conflict error, got %v", err)
+
+	ec.Running = true
+	ec.Pid = 42
+	assert.NilError(t, d.ContainerExecKill(ec.ID, 0))
+	assert.NilError(t, d.ContainerExecKill(ec.ID, uint64(syscall.SIGTERM)))
+	assert.Check(t, is.DeepEqual([]int{int(syscall.SIGKILL), int(syscall.SIGTERM)}, mc.signals))
+}
+
+func TestExecWait(t *testing.T) {
+	d, c := newExecTestDaemon(&execSignalMockContainerdClient{})
+	ec := exec.NewConfig()
+	ec.ContainerID = c.ID
+	d.registerExecCommand(c, ec)
+
+	go func() {
+		ec.Lock()
+		ec.SetExitCode(3)
+		ec.Unlock()
+	}()
+	exitCode, err := d.ContainerExecWait(context.Background(), ec.ID)
+	assert.NilError(t, err)
+	assert.Assert(t, exitCode != nil)
+	assert.Check(t, is.Equal(3, *exitCode))
+
+	_, err = d.ContainerExecWait(context.Background(), "nothing")
+	assert.Check(t, errdefs.IsNotFound(err), "expected a not found error, got %v", err)
+}
+
+func TestExecWaitCanceled(t *testing.T) {
+	d, c := newExecTestDaemon(&execSignalMockContainerdClient{})
+	ec := exec.NewConfig()
+	ec.ContainerID = c.ID
+	d.registerExecCommand(c, ec)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := d.ContainerExecWait(ctx, ec.ID)
+	assert.Check(t, is.Equal(context.DeadlineExceeded, err))
+}
+
+func TestExecList(t *testing.T) {
+	d, c := newExecTestDaemon(&execSignalMockContainerdClient{})
+	other := &container.Container{
+		ID:           "other_id",
+		ExecCommands: exec.NewStore(),
+		State:        &container.State{Running: true},
+	}
+	d.containers.Add(other.ID, other)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		ec := exec.NewConfig()
+		ec.ContainerID = c.ID
+		d.registerExecCommand(c, ec)
+		ids = append(ids, ec.ID)
+	}
+	ec := exec.NewConfig()
+	ec.ContainerID = other.ID
+	d.registerExecCommand(other, ec)
+
+	execs, err := d.ContainerExecList(c.ID)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(execs, 2))
+	for _, e := range execs {
+		assert.Check(t, is.Contains(ids, e.ID))
+		assert.Check(t, is.Equal(c.ID, e.ContainerID))
+	}
+}
+
+func TestExecTimeout(t *testing.T) {
+	mc := &execSignalMockContainerdClient{}
+	d, c := newExecTestDaemon(mc)
+	ec := exec.NewConfig()
+	ec.ContainerID = c.ID
+	ec.Timeout = 10 * time.Millisecond
+	d.registerExecCommand(c, ec)
+	mc.onSignal = func(signal int) {
+		ec.Lock()
+		ec.SetExitCode(128 + signal)
+		ec.Unlock()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.execTimeout(c, ec)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the exec to be terminated")
+	}
+	assert.Check(t, is.DeepEqual([]int{int(syscall.SIGTERM)}, mc.signals))
+}
@@ -0,0 +1,149 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/builder/fscache"
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/daemon/images"
+	"github.com/sirupsen/logrus"
+)
+
+// BuildCache is the build cache removed by the garbage collection.
+type BuildCache interface {
+	DiskUsage(ctx context.Context) (int64, error)
+	GC(ctx context.Context, policy fscache.GCPolicy) (uint64, error)
+}
+
+// StartGC starts the periodic garbage collection of unused images and of
+// the build cache, according to the gc options of the daemon configuration.
+// It stops when the daemon is shut down.
+func (daemon *Daemon) StartGC(buildCache BuildCache) {
+	go func() {
+		for {
+			conf := daemon.gcConfig()
+			interval := conf.Interval.Value()
+			if interval <= 0 {
+				interval = config.DefaultGCInterval
+			}
+			select {
+			case <-time.After(interval):
+			case <-daemon.gcStop:
+				return
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				select {
+				case <-daemon.gcStop:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			if err := daemon.gc(ctx, daemon.gcConfig(), buildCache); err != nil {
+				logrus.WithError(err).Error("garbage collection failed")
+			}
+			cancel()
+		}
+	}()
+}
+
+func (daemon *Daemon) gcConfig() config.GCConfig {
+	daemon.configStore.Lock()
+	defer daemon.configStore.Unlock()
+	return daemon.configStore.GCConfig
+}
+
+// gc removes the unused build cache, then the unused images, which exceed
+// the thresholds of the configuration. The build cache is collected first as
+// it is the cheapest to recreate. An event is logged for each image removed,
+// and a "gc" daemon event summarizes the space reclaimed.
+func (daemon *Daemon) gc(ctx context.Context, conf config.GCConfig, buildCache BuildCache) error {
+	if !conf.Enabled() {
+		return nil
+	}
+
+	// needed is the space to free to satisfy the min free space.
+	var needed int64
+	if conf.MinFreeSpace > 0 {
+		free, err := freeDiskSpace(daemon.root)
+		if err != nil {
+			return err
+		}
+		needed = conf.MinFreeSpace.Value() - int64(free)
+	}
+
+	var buildCacheReclaimed uint64
+	if buildCache != nil {
+		policy := fscache.GCPolicy{
+			MaxSize:         math.MaxUint64,
+			MaxKeepDuration: conf.MaxAge.Value(),
+		}
+		if conf.BuildCacheKeepStorage > 0 {
+			policy.MaxSize = uint64(conf.BuildCacheKeepStorage.Value())
+		}
+		if needed > 0 {
+			usage, err := buildCache.DiskUsage(ctx)
+			if err != nil {
+				return err
+			}
+			maxSize := usage - needed
+			if maxSize < 0 {
+				maxSize = 0
+			}
+			if uint64(maxSize) < policy.MaxSize {
+				policy.MaxSize = uint64(maxSize)
+			}
+		}
+		reclaimed, err := buildCache.GC(ctx, policy)
+		if err != nil {
+			return err
+		}
+		buildCacheReclaimed = reclaimed
+		needed -= int64(reclaimed)
+	}
+
+	policy := images.GCPolicy{
+		MaxAge: conf.MaxAge.Value(),
+		MinAge: conf.MinAge.Value(),
+	}
+	if conf.MaxImagesSize > 0 {
+		usage, err := daemon.imageService.LayerDiskUsage(ctx)
+		if err != nil {
+			return err
+		}
+		policy.ReclaimSpace = usage - conf.MaxImagesSize.Value()
+	}
+	if needed > policy.ReclaimSpace {
+		policy.ReclaimSpace = needed
+	}
+
+	var (
+		imagesDeleted   int
+		imagesReclaimed uint64
+	)
+	if policy.ReclaimSpace > 0 || policy.MaxAge > 0 {
+		rep, err := daemon.imageService.ImagesGC(ctx, policy)
+		if err != nil {
+			return err
+		}
+		for _, d := range rep.ImagesDeleted {
+			if d.Deleted != "" {
+				imagesDeleted++
+			}
+		}
+		imagesReclaimed = rep.SpaceReclaimed
+	}
+
+	if buildCacheReclaimed > 0 || imagesDeleted > 0 {
+		daemon.LogDaemonEventWithAttributes("gc", map[string]string{
+			"imagesDeleted":       strconv.Itoa(imagesDeleted),
+			"imagesReclaimed":     strconv.FormatUint(imagesReclaimed, 10),
+			"buildCacheReclaimed": strconv.FormatUint(buildCacheReclaimed, 10),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/builder/fscache"
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/opts"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type fakeBuildCache struct {
+	usage    int64
+	policies []fscache.GCPolicy
+}
+
+func (c *fakeBuildCache) DiskUsage(ctx context.Context) (int64, error) {
+	return c.usage, nil
+}
+
+func (c *fakeBuildCache) GC(ctx context.Context, policy fscache.GCPolicy) (uint64, error) {
+	c.policies = append(c.policies, policy)
+	return 0, nil
+}
+
+func TestGCDisabled(t *testing.T) {
+	d := &Daemon{}
+	buildCache := &fakeBuildCache{}
+	err := d.gc(context.Background(), config.GCConfig{Interval: opts.Duration(time.Minute)}, buildCache)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(buildCache.policies, 0))
+}
+
+func TestGCBuildCacheKeepStorage(t *testing.T) {
+	d := &Daemon{}
+	buildCache := &fakeBuildCache{usage: 2048}
+	err := d.gc(context.Background(), config.GCConfig{BuildCacheKeepStorage: opts.MemBytes(1024)}, buildCache)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]fscache.GCPolicy{{MaxSize: 1024}}, buildCache.policies))
+}
@@ -0,0 +1,15 @@
+// +build !windows
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import "golang.org/x/sys/unix"
+
+// freeDiskSpace returns the space available to unprivileged users on the
+// filesystem of path.
+func freeDiskSpace(path string) (uint64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
@@ -56,6 +56,7 @@ import (
 	"io/ioutil"
 	"path"
 	"path/filepath"
+	"sync"
 	"unsafe"
 
 	rsystem "github.com/opencontainers/runc/libcontainer/system"
@@ -71,11 +72,20 @@ type Quota struct {
 // Control - Context to be used by storage driver (e.g. overlay)
 // who wants to apply project quotas to container dirs
 type Control struct {
+	mu                sync.Mutex
 	backingFsBlockDev string
 	nextProjectID     uint32
 	quotas            map[string]uint32
 }
 
+// The project ids are allocated per filesystem, so a single Control is
+// shared by all the users of a backing filesystem (e.g. the storage driver
+// and the local volumes).
+var (
+	controlsMu sync.Mutex
+	controls   = make(map[uint64]*Control) // device number -> Control
+)
+
 // NewControl - initialize project quota support.
 // Test to make sure that quota can be set on a test dir and find
 // the first project id to be used for the next container create.
@@ -98,6 +108,9 @@ type Control struct {
 // on it. If that works, continue to scan existing containers to map allocated
 // project ids.
 //
+// If a Control was already created for a directory on the same backing
+// filesystem, the directories of basePath are added to it, and it is returned.
+//
 func NewControl(basePath string) (*Control, error) {
 	//
 	// If we are running in a user namespace quota won't be supported for
@@ -107,10 +120,26 @@ func NewControl(basePath string) (*Control, error) {
 		return nil, ErrQuotaNotSupported
 	}
 
+	var stat unix.Stat_t
+	if err := unix.Stat(basePath, &stat); err != nil {
+		return nil, err
+	}
+	dev := uint64(stat.Dev)
+
+	controlsMu.Lock()
+	defer controlsMu.Unlock()
+
+	if q, ok := controls[dev]; ok && isBackingFsDev(q.backingFsBlockDev, dev) {
+		if err := q.addBasePath(basePath); err != nil {
+			return nil, err
+		}
+		return q, nil
+	}
+
 	//
 	// create backing filesystem device node
 	//
-	backingFsBlockDev, err := makeBackingFsDev(basePath)
+	backingFsBlockDev, err := makeBackingFsDev(basePath, dev)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +174,7 @@ func NewControl(basePath string) (*Control, error) {
 		return nil, err
 	}
 
-	q := Control{
+	q := &Control{
 		backingFsBlockDev: backingFsBlockDev,
 		nextProjectID:     minProjectID + 1,
 		quotas:            make(map[string]uint32),
@@ -158,14 +187,43 @@ func NewControl(basePath string) (*Control, error) {
 	if err != nil {
 		return nil, err
 	}
+	controls[dev] = q
 
 	logrus.Debugf("NewControl(%s): nextProjectID = %d", basePath, q.nextProjectID)
-	return &q, nil
+	return q, nil
+}
+
+// addBasePath - map the project ids allocated to the directories of another
+// base path on the backing filesystem of the control
+func (q *Control) addBasePath(basePath string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	//
+	// The project id of the base path is a minimal id for its directories,
+	// as for the first base path
+	//
+	minProjectID, err := getProjectID(basePath)
+	if err != nil {
+		return err
+	}
+	if q.nextProjectID <= minProjectID+1 {
+		q.nextProjectID = minProjectID + 2
+	}
+
+	if err := q.findNextProjectID(basePath); err != nil {
+		return err
+	}
+
+	logrus.Debugf("NewControl(%s): nextProjectID = %d", basePath, q.nextProjectID)
+	return nil
 }
 
 // SetQuota - assign a unique project id to directory and set the quota limits
 // for that project id
 func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
 	projectID, ok := q.quotas[targetPath]
 	if !ok {
@@ -217,10 +275,33 @@ func setProjectQuota(backingFsBlockDev string, projectID uint32, quota Quota) er
 
 // GetQuota - get the quota limits of a directory that was configured with SetQuota
 func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	d, err := q.getProjectQuota(targetPath)
+	if err != nil {
+		return err
+	}
+	quota.Size = uint64(d.d_blk_hardlimit) * 512
+
+	return nil
+}
+
+// GetUsage - get the disk space used by a directory that was configured
+// with SetQuota
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	d, err := q.getProjectQuota(targetPath)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(d.d_bcount) * 512, nil
+}
 
+// getProjectQuota - get the quota of the project id of a directory that was
+// configured with SetQuota
+func (q *Control) getProjectQuota(targetPath string) (*C.fs_disk_quota_t, error) {
+	q.mu.Lock()
 	projectID, ok := q.quotas[targetPath]
+	q.mu.Unlock()
 	if !ok {
-		return fmt.Errorf("quota not found for path : %s", targetPath)
+		return nil, fmt.Errorf("quota not found for path : %s", targetPath)
 	}
 
 	//
@@ -235,12 +316,11 @@ func (q *Control) GetQuota(targetPath string, quota *Quota) error {
 		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
 		uintptr(unsafe.Pointer(&d)), 0, 0)
 	if errno != 0 {
-		return fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
+		return nil, fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
 			projectID, q.backingFsBlockDev, errno.Error())
 	}
-	quota.Size = uint64(d.d_blk_hardlimit) * 512
 
-	return nil
+	return &d, nil
 }
 
 // getProjectID - get the project id of path on xfs
@@ -341,16 +421,15 @@ func getDirFd(dir *C.DIR) uintptr {
 // Get the backing block device of the driver home directory
 // and create a block device node under the home directory
 // to be used by quotactl commands
-func makeBackingFsDev(home string) (string, error) {
-	var stat unix.Stat_t
-	if err := unix.Stat(home, &stat); err != nil {
-		return "", err
+func makeBackingFsDev(home string, dev uint64) (string, error) {
+	backingFsBlockDev := path.Join(home, "backingFsBlockDev")
+	if isBackingFsDev(backingFsBlockDev, dev) {
+		return backingFsBlockDev, nil
 	}
 
-	backingFsBlockDev := path.Join(home, "backingFsBlockDev")
 	// Re-create just in case someone copied the home directory over to a new device
 	unix.Unlink(backingFsBlockDev)
-	err := unix.Mknod(backingFsBlockDev, unix.S_IFBLK|0600, int(stat.Dev))
+	err := unix.Mknod(backingFsBlockDev, unix.S_IFBLK|0600, int(dev))
 	switch err {
 	case nil:
 		return backingFsBlockDev, nil
@@ -363,6 +442,15 @@ func makeBackingFsDev(home string) (string, error) {
 	}
 }
 
+// isBackingFsDev - check that the node at path is the block device dev
+func isBackingFsDev(path string, dev uint64) bool {
+	var stat unix.Stat_t
+	if err := unix.Lstat(path, &stat); err != nil {
+		return false
+	}
+	return stat.Mode&unix.S_IFMT == unix.S_IFBLK && uint64(stat.Rdev) == dev
+}
+
 func hasQuotaSupport(backingFsBlockDev string) (bool, error) {
 	var cs = C.CString(backingFsBlockDev)
 	defer free(cs)
@@ -58,6 +58,8 @@ func TestBlockDev(t *testing.T) {
 	t.Run("testSmallerThanQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testSmallerThanQuota)))
 	t.Run("testBiggerThanQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testBiggerThanQuota)))
 	t.Run("testRetrieveQuota", wrapMountTest(imageFileName, true, wrapQuotaTest(testRetrieveQuota)))
+	t.Run("testRetrieveUsage", wrapMountTest(imageFileName, true, wrapQuotaTest(testRetrieveUsage)))
+	t.Run("testSharedControl", wrapMountTest(imageFileName, true, testSharedControl))
 }
 
 func wrapMountTest(imageFileName string, enableQuota bool, testFunc func(t *testing.T, mountPoint, backingFsDev string)) func(*testing.T) {
@@ -86,7 +88,9 @@ func wrapMountTest(imageFileName string, enableQuota bool, testFunc func(t *test
 			assert.NilError(t, unix.Unmount(mountPoint, 0))
 		}()
 
-		backingFsDev, err := makeBackingFsDev(mountPoint)
+		var stat unix.Stat_t
+		assert.NilError(t, unix.Stat(mountPoint, &stat))
+		backingFsDev, err := makeBackingFsDev(mountPoint, uint64(stat.Dev))
 		assert.NilError(t, err)
 
 		testFunc(t, mountPoint, backingFsDev)
@@ -150,3 +154,45 @@ func testRetrieveQuota(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir
 	assert.NilError(t, ctrl.GetQuota(testSubDir, &q))
 	assert.Check(t, is.Equal(uint64(testQuotaSize), q.Size))
 }
+
+func testRetrieveUsage(t *testing.T, ctrl *Control, homeDir, testDir, testSubDir string) {
+	assert.NilError(t, ctrl.SetQuota(testSubDir, Quota{testQuotaSize}))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(testSubDir, "usage"), make([]byte, testQuotaSize/2), 0644))
+	unix.Sync()
+
+	usage, err := ctrl.GetUsage(testSubDir)
+	assert.NilError(t, err)
+	assert.Check(t, usage >= testQuotaSize/2, "usage: %d", usage)
+}
+
+func testSharedControl(t *testing.T, mountPoint, backingFsDev string) {
+	dir1, err := ioutil.TempDir(mountPoint, "base1")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir1)
+	dir2, err := ioutil.TempDir(mountPoint, "base2")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir2)
+
+	ctrl1, err := NewControl(dir1)
+	assert.NilError(t, err)
+	ctrl2, err := NewControl(dir2)
+	assert.NilError(t, err)
+	assert.Check(t, ctrl1 == ctrl2)
+
+	// The backing device node is not created again in the second base path.
+	_, err = os.Lstat(filepath.Join(dir2, "backingFsBlockDev"))
+	assert.Check(t, os.IsNotExist(err))
+
+	sub1, err := ioutil.TempDir(dir1, "quota-test")
+	assert.NilError(t, err)
+	sub2, err := ioutil.TempDir(dir2, "quota-test")
+	assert.NilError(t, err)
+	assert.NilError(t, ctrl1.SetQuota(sub1, Quota{testQuotaSize}))
+	assert.NilError(t, ctrl2.SetQuota(sub2, Quota{testQuotaSize}))
+
+	id1, err := getProjectID(sub1)
+	assert.NilError(t, err)
+	id2, err := getProjectID(sub2)
+	assert.NilError(t, err)
+	assert.Check(t, id1 != id2)
+}
@@ -3,10 +3,18 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -14,6 +22,8 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/pkg/signal"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -44,7 +54,24 @@ const (
 const (
 	// Exit status codes that can be returned by the probe command.
 
-	exitStatusHealthy = 0 // Container is healthy
+	exitStatusHealthy   = 0 // Container is healthy
+	exitStatusUnhealthy = 1 // Container is unhealthy
+)
+
+const (
+	// Actions taken when a container becomes unhealthy.
+
+	unhealthyActionNone    = "none"
+	unhealthyActionRestart = "restart"
+	unhealthyActionStop    = "stop"
+	unhealthyActionKill    = "kill"
+	unhealthyActionExec    = "exec"
+)
+
+const (
+	// Default range of HTTP status codes considered healthy by the HTTP probe.
+	defaultHTTPProbeMinStatus = 200
+	defaultHTTPProbeMaxStatus = 399
 )
 
 // probe implementations know how to run a particular type of probe.
@@ -67,6 +94,12 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, cntr *container.Container
 	if p.shell {
 		cmdSlice = append(getShell(cntr.Config), cmdSlice...)
 	}
+	return execInContainer(ctx, d, cntr, cmdSlice)
+}
+
+// execInContainer runs cmdSlice in the container, and returns its exit code
+// and output (if any).
+func execInContainer(ctx context.Context, d *Daemon, cntr *container.Container, cmdSlice strslice.StrSlice) (*types.HealthcheckResult, error) {
 	entrypoint, args := d.getEntrypointAndArgs(strslice.StrSlice{}, cmdSlice)
 	execConfig := exec.NewConfig()
 	execConfig.OpenStdin = false
@@ -103,7 +136,7 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, cntr *container.Container
 		return nil, err
 	}
 	if info.ExitCode == nil {
-		return nil, fmt.Errorf("healthcheck for container %s has no exit code", cntr.ID)
+		return nil, fmt.Errorf("command %q in container %s has no exit code", cmdSlice, cntr.ID)
 	}
 	// Note: Go's json package will handle invalid UTF-8 for us
 	out := output.String()
@@ -114,6 +147,211 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, cntr *container.Container
 	}, nil
 }
 
+// httpProbe implements the "HTTP" probe type. The daemon performs an HTTP GET
+// request from within the network namespace of the container.
+type httpProbe struct{}
+
+func (p *httpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	cfg, err := parseHTTPProbe(cntr.Config.Healthcheck.Test[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext:       containerDialer(cntr),
+			DisableKeepAlives: true,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: cfg.insecure},
+		},
+		// Redirects are reported as-is, they count as healthy by default.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	req, err := http.NewRequest(http.MethodGet, cfg.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	output := &limitedBuffer{}
+	if _, err := io.Copy(output, resp.Body); err != nil {
+		return nil, err
+	}
+	body := output.String()
+
+	exitCode := exitStatusHealthy
+	switch {
+	case resp.StatusCode < cfg.minStatus || resp.StatusCode > cfg.maxStatus:
+		exitCode = exitStatusUnhealthy
+	case cfg.body != nil && !cfg.body.MatchString(body):
+		exitCode = exitStatusUnhealthy
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Output:   fmt.Sprintf("HTTP %s\n%s", resp.Status, body),
+	}, nil
+}
+
+// httpProbeConfig is the parsed form of the arguments of an "HTTP" healthcheck.
+type httpProbeConfig struct {
+	url       string
+	minStatus int
+	maxStatus int
+	body      *regexp.Regexp
+	insecure  bool
+}
+
+// parseHTTPProbe parses the arguments of an "HTTP" healthcheck, which are the
+// URL to request followed by optional "status=<code>[-<code>]",
+// "body=<regexp>" and "insecure=<bool>" options.
+func parseHTTPProbe(args []string) (*httpProbeConfig, error) {
+	if len(args) == 0 {
+		return nil, errors.New("missing URL for HTTP healthcheck")
+	}
+	u, err := url.Parse(args[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid URL for HTTP healthcheck")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Errorf("invalid URL for HTTP healthcheck %q: an absolute http or https URL is required", args[0])
+	}
+	if _, err := probeHost(u.Hostname()); err != nil {
+		return nil, errors.Wrap(err, "invalid URL for HTTP healthcheck")
+	}
+
+	cfg := &httpProbeConfig{
+		url:       u.String(),
+		minStatus: defaultHTTPProbeMinStatus,
+		maxStatus: defaultHTTPProbeMaxStatus,
+	}
+	for _, opt := range args[1:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid HTTP healthcheck option %q", opt)
+		}
+		switch kv[0] {
+		case "status":
+			statuses := strings.SplitN(kv[1], "-", 2)
+			if cfg.minStatus, err = strconv.Atoi(statuses[0]); err != nil {
+				return nil, errors.Errorf("invalid HTTP healthcheck status %q", kv[1])
+			}
+			cfg.maxStatus = cfg.minStatus
+			if len(statuses) == 2 {
+				if cfg.maxStatus, err = strconv.Atoi(statuses[1]); err != nil {
+					return nil, errors.Errorf("invalid HTTP healthcheck status %q", kv[1])
+				}
+			}
+			if cfg.minStatus > cfg.maxStatus {
+				return nil, errors.Errorf("invalid HTTP healthcheck status %q", kv[1])
+			}
+		case "body":
+			if cfg.body, err = regexp.Compile(kv[1]); err != nil {
+				return nil, errors.Wrap(err, "invalid HTTP healthcheck body pattern")
+			}
+		case "insecure":
+			// Certificates are verified unless this is set, as with
+			// self-signed certificates.
+			if cfg.insecure, err = strconv.ParseBool(kv[1]); err != nil {
+				return nil, errors.Errorf("invalid HTTP healthcheck insecure value %q", kv[1])
+			}
+		default:
+			return nil, errors.Errorf("unknown HTTP healthcheck option %q", kv[0])
+		}
+	}
+	return cfg, nil
+}
+
+// tcpProbe implements the "TCP" probe type. The container is healthy if the
+// daemon can open a TCP connection to the address from within the network
+// namespace of the container.
+type tcpProbe struct{}
+
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	address, err := parseTCPProbe(cntr.Config.Healthcheck.Test[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := containerDialer(cntr)(ctx, "tcp", address)
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	conn.Close()
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   "connected to " + address,
+	}, nil
+}
+
+// parseTCPProbe parses the arguments of a "TCP" healthcheck, which is a
+// single "[host]:port" address.
+func parseTCPProbe(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("TCP healthcheck requires exactly one address")
+	}
+	host, port, err := net.SplitHostPort(args[0])
+	if err != nil {
+		return "", errors.Wrap(err, "invalid address for TCP healthcheck")
+	}
+	if host, err = probeHost(host); err != nil {
+		return "", errors.Wrap(err, "invalid address for TCP healthcheck")
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", errors.Errorf("invalid port for TCP healthcheck: %q", port)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// probeHost returns the IP address to connect to for the host of an HTTP or
+// TCP healthcheck. Only IP addresses and "localhost" (or an empty host) are
+// accepted: names would be resolved by the daemon, not in the container.
+func probeHost(host string) (string, error) {
+	if host == "" || host == "localhost" {
+		return "127.0.0.1", nil
+	}
+	if net.ParseIP(host) == nil {
+		return "", errors.Errorf("host %q is not an IP address", host)
+	}
+	return host, nil
+}
+
+// validateHealthcheckTest checks the probe type and arguments of a healthcheck
+// for a container of the given platform.
+func validateHealthcheckTest(platform string, test []string) error {
+	if len(test) == 0 {
+		return nil
+	}
+	// the daemon dials HTTP and TCP probes from the network namespace of the
+	// container, which only exists for Linux containers
+	if (test[0] == "HTTP" || test[0] == "TCP") && platform == "windows" {
+		return errors.Errorf("%s healthchecks are not supported for Windows containers", test[0])
+	}
+	switch test[0] {
+	case "HTTP":
+		_, err := parseHTTPProbe(test[1:])
+		return err
+	case "TCP":
+		_, err := parseTCPProbe(test[1:])
+		return err
+	}
+	return nil
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
 	c.Lock()
@@ -179,7 +417,109 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 	current := h.Status()
 	if oldStatus != current {
 		d.LogContainerEvent(c, "health_status: "+current)
+
+		if current == types.Unhealthy && c.HostConfig != nil && c.HostConfig.UnhealthyPolicy != nil {
+			policy := *c.HostConfig.UnhealthyPolicy
+			go d.handleUnhealthy(c, policy)
+		}
+	}
+}
+
+// validateUnhealthyPolicy checks the action to take when a container becomes unhealthy.
+func validateUnhealthyPolicy(p *containertypes.UnhealthyPolicy) error {
+	if p == nil {
+		return nil
+	}
+	switch p.Action {
+	case "", unhealthyActionNone, unhealthyActionRestart, unhealthyActionStop:
+	case unhealthyActionKill:
+		if p.Signal != "" {
+			if _, err := signal.ParseSignal(p.Signal); err != nil {
+				return err
+			}
+		}
+	case unhealthyActionExec:
+		if len(p.Cmd) == 0 {
+			return errors.New("missing command for the 'exec' unhealthy action")
+		}
+	default:
+		return errors.Errorf("invalid unhealthy action '%s'", p.Action)
+	}
+	if p.Signal != "" && p.Action != unhealthyActionKill {
+		return errors.Errorf("a signal cannot be used with the '%s' unhealthy action", p.Action)
+	}
+	if len(p.Cmd) != 0 && p.Action != unhealthyActionExec {
+		return errors.Errorf("a command cannot be used with the '%s' unhealthy action", p.Action)
+	}
+	return nil
+}
+
+// handleUnhealthy takes the action configured by the policy on a container
+// which just became unhealthy.
+func (d *Daemon) handleUnhealthy(c *container.Container, policy containertypes.UnhealthyPolicy) {
+	var err error
+	switch policy.Action {
+	case unhealthyActionRestart:
+		err = d.restartUnhealthy(c)
+	case unhealthyActionStop:
+		err = d.containerStop(c, c.StopTimeout())
+	case unhealthyActionKill:
+		sig := int(syscall.SIGKILL)
+		if policy.Signal != "" {
+			s, _ := signal.ParseSignal(policy.Signal)
+			sig = int(s)
+		}
+		err = d.killWithSignal(c, sig)
+	case unhealthyActionExec:
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutWithDefault(c.Config.Healthcheck.Timeout, defaultProbeTimeout))
+		defer cancel()
+		var result *types.HealthcheckResult
+		if result, err = execInContainer(ctx, d, c, policy.Cmd); err == nil && result.ExitCode != 0 {
+			err = errors.Errorf("command exited with code %d: %s", result.ExitCode, result.Output)
+		}
+	default:
+		return
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Errorf("failed to %s unhealthy container", policy.Action)
+		return
+	}
+	d.LogContainerEventWithAttributes(c, "health_action", map[string]string{"action": policy.Action})
+}
+
+// restartUnhealthy stops the container without marking it as manually
+// stopped, so that the restart manager restarts it with its usual backoff.
+func (d *Daemon) restartUnhealthy(c *container.Container) error {
+	c.Lock()
+	if !c.Running || c.Restarting {
+		c.Unlock()
+		return nil
+	}
+	rm := c.RestartManager()
+	rm.RestartOnNextExit()
+	c.Unlock()
+
+	err := d.stopUnhealthy(c)
+	if err != nil {
+		// The container may still be running: don't let an unrelated exit
+		// restart it later.
+		rm.ClearRestartOnNextExit()
+	}
+	return err
+}
+
+func (d *Daemon) stopUnhealthy(c *container.Container) error {
+	if err := d.kill(c, c.StopSignal()); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.StopTimeout())*time.Second)
+	defer cancel()
+	if status := <-c.Wait(ctx, container.WaitConditionNotRunning); status.Err() == nil {
+		return nil
 	}
+	logrus.WithField("container", c.ID).Infof("Unhealthy container failed to exit within %d seconds of signal %d - using the force", c.StopTimeout(), c.StopSignal())
+	return d.kill(c, int(syscall.SIGKILL))
 }
 
 // Run the container's monitoring thread until notified via "stop".
@@ -257,10 +597,14 @@ func getProbe(c *container.Container) probe {
 		return &cmdProbe{shell: false}
 	case "CMD-SHELL":
 		return &cmdProbe{shell: true}
+	case "HTTP":
+		return &httpProbe{}
+	case "TCP":
+		return &tcpProbe{}
 	case "NONE":
 		return nil
 	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
+		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD', 'CMD-SHELL', 'HTTP' or 'TCP') in container %s", config.Test[0], c.ID)
 		return nil
 	}
 }
@@ -0,0 +1,70 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"net"
+	"runtime"
+
+	"github.com/docker/docker/container"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
+)
+
+// containerDialer returns a dial function which opens connections from within
+// the network namespace of the container, so that probes can reach services
+// listening on the container's loopback interface. Host names are not resolved,
+// as the lookup would use the daemon's resolver configuration.
+func containerDialer(c *container.Container) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if host, err = probeHost(host); err != nil {
+			return nil, err
+		}
+		address = net.JoinHostPort(host, port)
+
+		pid := c.GetPID()
+		if pid == 0 {
+			return nil, errors.Errorf("container %s is not running", c.ID)
+		}
+
+		// The socket must be created on a thread that is in the container's
+		// network namespace; once created it stays bound to that namespace.
+		runtime.LockOSThread()
+		origns, err := netns.Get()
+		if err != nil {
+			runtime.UnlockOSThread()
+			return nil, errors.Wrap(err, "failed to get current network namespace")
+		}
+		defer origns.Close()
+
+		ns, err := netns.GetFromPid(pid)
+		if err != nil {
+			runtime.UnlockOSThread()
+			return nil, errors.Wrapf(err, "failed to get network namespace of container %s", c.ID)
+		}
+		defer ns.Close()
+
+		if err := netns.Set(ns); err != nil {
+			runtime.UnlockOSThread()
+			return nil, errors.Wrapf(err, "failed to enter network namespace of container %s", c.ID)
+		}
+
+		// Disable dual-stack fast fallback so the dial does not happen on
+		// another goroutine (and thus OS thread).
+		dialer := net.Dialer{FallbackDelay: -1}
+		conn, dialErr := dialer.DialContext(ctx, network, address)
+
+		if err := netns.Set(origns); err != nil {
+			// Keep the thread locked so it is terminated when this goroutine
+			// exits instead of being reused in the wrong namespace.
+			logrus.WithError(err).Error("failed to restore network namespace after health check")
+		} else {
+			runtime.UnlockOSThread()
+		}
+		return conn, dialErr
+	}
+}
@@ -152,3 +152,143 @@ func TestHealthStates(t *testing.T) {
 		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
 	}
 }
+
+func TestParseHTTPProbe(t *testing.T) {
+	cfg, err := parseHTTPProbe([]string{"http://localhost:8080/health"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.minStatus != 200 || cfg.maxStatus != 399 || cfg.body != nil || cfg.insecure {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	cfg, err = parseHTTPProbe([]string{"https://localhost/health", "status=204", "body=^ok$"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.minStatus != 204 || cfg.maxStatus != 204 {
+		t.Errorf("expected status 204, got %d-%d", cfg.minStatus, cfg.maxStatus)
+	}
+	if cfg.body == nil || !cfg.body.MatchString("ok") || cfg.body.MatchString("not ok") {
+		t.Errorf("unexpected body pattern: %v", cfg.body)
+	}
+
+	cfg, err = parseHTTPProbe([]string{"http://127.0.0.1/", "status=200-299"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.minStatus != 200 || cfg.maxStatus != 299 {
+		t.Errorf("expected status 200-299, got %d-%d", cfg.minStatus, cfg.maxStatus)
+	}
+
+	cfg, err = parseHTTPProbe([]string{"https://[::1]:8443/", "insecure=true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.insecure {
+		t.Error("expected certificate verification to be disabled")
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"localhost:8080"},
+		{"ftp://localhost/"},
+		{"http://localhost/", "status=abc"},
+		{"http://localhost/", "status=300-200"},
+		{"http://localhost/", "body=("},
+		{"http://localhost/", "method=POST"},
+		{"http://localhost/", "status"},
+		{"http://localhost/", "insecure=maybe"},
+		{"http://example.com/"},
+	} {
+		if _, err := parseHTTPProbe(args); err == nil {
+			t.Errorf("expected error for %q", args)
+		}
+	}
+}
+
+func TestParseTCPProbe(t *testing.T) {
+	for args, expected := range map[string]string{
+		":5432":          "127.0.0.1:5432",
+		"localhost:5432": "127.0.0.1:5432",
+		"10.0.0.2:5432":  "10.0.0.2:5432",
+		"[::1]:80":       "[::1]:80",
+	} {
+		address, err := parseTCPProbe([]string{args})
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", args, err)
+			continue
+		}
+		if address != expected {
+			t.Errorf("expected %q for %q, got %q", expected, args, address)
+		}
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"localhost"},
+		{"localhost:http"},
+		{"localhost:70000"},
+		{"db.example.com:5432"},
+		{"localhost:80", "localhost:81"},
+	} {
+		if _, err := parseTCPProbe(args); err == nil {
+			t.Errorf("expected error for %q", args)
+		}
+	}
+}
+
+func TestValidateHealthcheckTest(t *testing.T) {
+	for _, test := range [][]string{
+		nil,
+		{"NONE"},
+		{"CMD", "true"},
+		{"HTTP", "http://localhost:8080/health"},
+		{"TCP", "localhost:5432"},
+	} {
+		if err := validateHealthcheckTest("linux", test); err != nil {
+			t.Errorf("unexpected error for %q: %v", test, err)
+		}
+	}
+
+	for _, test := range [][]string{
+		{"HTTP", "http://localhost:8080/health"},
+		{"TCP", "localhost:5432"},
+	} {
+		if err := validateHealthcheckTest("windows", test); err == nil {
+			t.Errorf("expected error for %q on windows", test)
+		}
+	}
+	if err := validateHealthcheckTest("windows", []string{"CMD", "true"}); err != nil {
+		t.Errorf("unexpected error for a command healthcheck on windows: %v", err)
+	}
+}
+
+func TestValidateUnhealthyPolicy(t *testing.T) {
+	for _, p := range []*containertypes.UnhealthyPolicy{
+		nil,
+		{},
+		{Action: "none"},
+		{Action: "restart"},
+		{Action: "stop"},
+		{Action: "kill"},
+		{Action: "kill", Signal: "SIGUSR1"},
+		{Action: "exec", Cmd: []string{"/bin/reload"}},
+	} {
+		if err := validateUnhealthyPolicy(p); err != nil {
+			t.Errorf("unexpected error for %+v: %v", p, err)
+		}
+	}
+
+	for _, p := range []*containertypes.UnhealthyPolicy{
+		{Action: "reboot"},
+		{Action: "kill", Signal: "SIGFOO"},
+		{Action: "exec"},
+		{Action: "restart", Signal: "SIGTERM"},
+		{Action: "stop", Cmd: []string{"/bin/reload"}},
+	} {
+		if err := validateUnhealthyPolicy(p); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/docker/docker/container"
+)
+
+func containerDialer(c *container.Container) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		return nil, errors.New("HTTP and TCP health checks are not supported on this platform")
+	}
+}
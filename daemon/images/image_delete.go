@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/system"
 	"github.com/pkg/errors"
@@ -61,6 +62,14 @@ const (
 // conflict will not be reported.
 //
 func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.ImageDeleteResponseItem, error) {
+	return i.imageDelete(imageRef, force, prune, nil)
+}
+
+// imageDelete deletes the image as described for ImageDelete. If dryRun is
+// not nil, nothing is deleted, and the deletion is recorded in dryRun
+// instead, so that the records of the deletion of several images can be
+// computed.
+func (i *ImageService) imageDelete(imageRef string, force, prune bool, dryRun *deleteDryRun) ([]types.ImageDeleteResponseItem, error) {
 	start := time.Now()
 	records := []types.ImageDeleteResponseItem{}
 
@@ -68,12 +77,15 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 	if err != nil {
 		return nil, err
 	}
+	if dryRun != nil && dryRun.isDeleted(imageRef, img.ID()) {
+		return nil, errdefs.NotFound(errors.Errorf("No such image: %s", imageRef))
+	}
 	if !system.IsOSSupported(img.OperatingSystem()) {
 		return nil, errors.Errorf("unable to delete image: %q", system.ErrNotSupportedOperatingSystem)
 	}
 
 	imgID := img.ID()
-	repoRefs := i.referenceStore.References(imgID.Digest())
+	repoRefs := i.references(imgID, dryRun)
 
 	using := func(c *container.Container) bool {
 		return c.ImageID == imgID
@@ -101,17 +113,19 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 			return nil, err
 		}
 
-		parsedRef, err = i.removeImageRef(parsedRef)
+		parsedRef, err = i.removeImageRef(parsedRef, dryRun)
 		if err != nil {
 			return nil, err
 		}
 
 		untaggedRecord := types.ImageDeleteResponseItem{Untagged: reference.FamiliarString(parsedRef)}
 
-		i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		if dryRun == nil {
+			i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		}
 		records = append(records, untaggedRecord)
 
-		repoRefs = i.referenceStore.References(imgID.Digest())
+		repoRefs = i.references(imgID, dryRun)
 
 		// If a tag reference was removed and the only remaining
 		// references to the same repository are digest references,
@@ -129,7 +143,7 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 				var remainingRefs []reference.Named
 				for _, repoRef := range repoRefs {
 					if _, repoRefIsCanonical := repoRef.(reference.Canonical); repoRefIsCanonical && parsedRef.Name() == repoRef.Name() {
-						if _, err := i.removeImageRef(repoRef); err != nil {
+						if _, err := i.removeImageRef(repoRef, dryRun); err != nil {
 							return records, err
 						}
 
@@ -159,29 +173,33 @@ func (i *ImageService) ImageDelete(imageRef string, force, prune bool) ([]types.
 			if !force {
 				c |= conflictSoft &^ conflictActiveReference
 			}
-			if conflict := i.checkImageDeleteConflict(imgID, c); conflict != nil {
+			if conflict := i.checkImageDeleteConflict(imgID, c, dryRun); conflict != nil {
 				return nil, conflict
 			}
 
 			for _, repoRef := range repoRefs {
-				parsedRef, err := i.removeImageRef(repoRef)
+				parsedRef, err := i.removeImageRef(repoRef, dryRun)
 				if err != nil {
 					return nil, err
 				}
 
 				untaggedRecord := types.ImageDeleteResponseItem{Untagged: reference.FamiliarString(parsedRef)}
 
-				i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+				if dryRun == nil {
+					i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+				}
 				records = append(records, untaggedRecord)
 			}
 		}
 	}
 
-	if err := i.imageDeleteHelper(imgID, &records, force, prune, removedRepositoryRef); err != nil {
+	if err := i.imageDeleteHelper(imgID, &records, force, prune, removedRepositoryRef, dryRun); err != nil {
 		return nil, err
 	}
 
-	imageActions.WithValues("delete").UpdateSince(start)
+	if dryRun == nil {
+		imageActions.WithValues("delete").UpdateSince(start)
+	}
 
 	return records, nil
 }
@@ -230,9 +248,14 @@ func isImageIDPrefix(imageID, possiblePrefix string) bool {
 // repositoryRef must not be an image ID but a repository name followed by an
 // optional tag or digest reference. If tag or digest is omitted, the default
 // tag is used. Returns the resolved image reference and an error.
-func (i *ImageService) removeImageRef(ref reference.Named) (reference.Named, error) {
+func (i *ImageService) removeImageRef(ref reference.Named, dryRun *deleteDryRun) (reference.Named, error) {
 	ref = reference.TagNameOnly(ref)
 
+	if dryRun != nil {
+		dryRun.removedRefs[ref.String()] = true
+		return ref, nil
+	}
+
 	// Ignore the boolean value returned, as far as we're concerned, this
 	// is an idempotent operation and it's okay if the reference didn't
 	// exist in the first place.
@@ -246,18 +269,20 @@ func (i *ImageService) removeImageRef(ref reference.Named) (reference.Named, err
 // on the first encountered error. Removed references are logged to this
 // daemon's event service. An "Untagged" types.ImageDeleteResponseItem is added to the
 // given list of records.
-func (i *ImageService) removeAllReferencesToImageID(imgID image.ID, records *[]types.ImageDeleteResponseItem) error {
-	imageRefs := i.referenceStore.References(imgID.Digest())
+func (i *ImageService) removeAllReferencesToImageID(imgID image.ID, records *[]types.ImageDeleteResponseItem, dryRun *deleteDryRun) error {
+	imageRefs := i.references(imgID, dryRun)
 
 	for _, imageRef := range imageRefs {
-		parsedRef, err := i.removeImageRef(imageRef)
+		parsedRef, err := i.removeImageRef(imageRef, dryRun)
 		if err != nil {
 			return err
 		}
 
 		untaggedRecord := types.ImageDeleteResponseItem{Untagged: reference.FamiliarString(parsedRef)}
 
-		i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		if dryRun == nil {
+			i.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		}
 		*records = append(*records, untaggedRecord)
 	}
 
@@ -297,15 +322,15 @@ func (idc *imageDeleteConflict) Conflict() {}
 // conflict is encountered, it will be returned immediately without deleting
 // the image. If quiet is true, any encountered conflicts will be ignored and
 // the function will return nil immediately without deleting the image.
-func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageDeleteResponseItem, force, prune, quiet bool) error {
+func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageDeleteResponseItem, force, prune, quiet bool, dryRun *deleteDryRun) error {
 	// First, determine if this image has any conflicts. Ignore soft conflicts
 	// if force is true.
 	c := conflictHard
 	if !force {
 		c |= conflictSoft
 	}
-	if conflict := i.checkImageDeleteConflict(imgID, c); conflict != nil {
-		if quiet && (!i.imageIsDangling(imgID) || conflict.used) {
+	if conflict := i.checkImageDeleteConflict(imgID, c, dryRun); conflict != nil {
+		if quiet && (!i.imageIsDangling(imgID, dryRun) || conflict.used) {
 			// Ignore conflicts UNLESS the image is "dangling" or not being used in
 			// which case we want the user to know.
 			return nil
@@ -323,16 +348,25 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 	}
 
 	// Delete all repository tag/digest references to this image.
-	if err := i.removeAllReferencesToImageID(imgID, records); err != nil {
+	if err := i.removeAllReferencesToImageID(imgID, records, dryRun); err != nil {
 		return err
 	}
 
-	removedLayers, err := i.imageStore.Delete(imgID)
-	if err != nil {
-		return err
+	var removedLayers []layer.Metadata
+	if dryRun != nil {
+		img, err := i.imageStore.Get(imgID)
+		if err != nil {
+			return err
+		}
+		removedLayers = dryRun.deleteImage(imgID, img.RootFS.ChainID())
+	} else {
+		removedLayers, err = i.imageStore.Delete(imgID)
+		if err != nil {
+			return err
+		}
+		i.LogImageEvent(imgID.String(), imgID.String(), "delete")
 	}
 
-	i.LogImageEvent(imgID.String(), imgID.String(), "delete")
 	*records = append(*records, types.ImageDeleteResponseItem{Deleted: imgID.String()})
 	for _, removedLayer := range removedLayers {
 		*records = append(*records, types.ImageDeleteResponseItem{Deleted: removedLayer.ChainID.String()})
@@ -347,7 +381,7 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 	// either running or stopped).
 	// Do not force prunings, but do so quietly (stopping on any encountered
 	// conflicts).
-	return i.imageDeleteHelper(parent, records, false, true, true)
+	return i.imageDeleteHelper(parent, records, false, true, true, dryRun)
 }
 
 // checkImageDeleteConflict determines whether there are any conflicts
@@ -356,9 +390,9 @@ func (i *ImageService) imageDeleteHelper(imgID image.ID, records *[]types.ImageD
 // using the image. A soft conflict is any tags/digest referencing the given
 // image or any stopped container using the image. If ignoreSoftConflicts is
 // true, this function will not check for soft conflict conditions.
-func (i *ImageService) checkImageDeleteConflict(imgID image.ID, mask conflictType) *imageDeleteConflict {
+func (i *ImageService) checkImageDeleteConflict(imgID image.ID, mask conflictType, dryRun *deleteDryRun) *imageDeleteConflict {
 	// Check if the image has any descendant images.
-	if mask&conflictDependentChild != 0 && len(i.imageStore.Children(imgID)) > 0 {
+	if mask&conflictDependentChild != 0 && len(i.children(imgID, dryRun)) > 0 {
 		return &imageDeleteConflict{
 			hard:    true,
 			imgID:   imgID,
@@ -382,7 +416,7 @@ func (i *ImageService) checkImageDeleteConflict(imgID image.ID, mask conflictTyp
 	}
 
 	// Check if any repository tags/digest reference this image.
-	if mask&conflictActiveReference != 0 && len(i.referenceStore.References(imgID.Digest())) > 0 {
+	if mask&conflictActiveReference != 0 && len(i.references(imgID, dryRun)) > 0 {
 		return &imageDeleteConflict{
 			imgID:   imgID,
 			message: "image is referenced in multiple repositories",
@@ -409,6 +443,114 @@ func (i *ImageService) checkImageDeleteConflict(imgID image.ID, mask conflictTyp
 // imageIsDangling returns whether the given image is "dangling" which means
 // that there are no repository references to the given image and it has no
 // child images.
-func (i *ImageService) imageIsDangling(imgID image.ID) bool {
-	return !(len(i.referenceStore.References(imgID.Digest())) > 0 || len(i.imageStore.Children(imgID)) > 0)
+func (i *ImageService) imageIsDangling(imgID image.ID, dryRun *deleteDryRun) bool {
+	return !(len(i.references(imgID, dryRun)) > 0 || len(i.children(imgID, dryRun)) > 0)
+}
+
+// references returns the repository references to the given image, except
+// those removed by the dry run, if any.
+func (i *ImageService) references(imgID image.ID, dryRun *deleteDryRun) []reference.Named {
+	refs := i.referenceStore.References(imgID.Digest())
+	if dryRun == nil {
+		return refs
+	}
+	var remaining []reference.Named
+	for _, ref := range refs {
+		if !dryRun.removedRefs[ref.String()] {
+			remaining = append(remaining, ref)
+		}
+	}
+	return remaining
+}
+
+// children returns the child images of the given image, except those
+// deleted by the dry run, if any.
+func (i *ImageService) children(imgID image.ID, dryRun *deleteDryRun) []image.ID {
+	children := i.imageStore.Children(imgID)
+	if dryRun == nil {
+		return children
+	}
+	var remaining []image.ID
+	for _, id := range children {
+		if !dryRun.deletedImages[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining
+}
+
+// deleteDryRun records the references removed and the images deleted by a
+// simulated deletion, and tracks the references to the layers the same way
+// as the layer stores, to find the layers which would be removed.
+type deleteDryRun struct {
+	removedRefs   map[string]bool
+	deletedImages map[image.ID]bool
+	layers        map[layer.ChainID]layer.Layer
+	layerRefs     map[layer.ChainID]int
+}
+
+func (i *ImageService) newDeleteDryRun() *deleteDryRun {
+	dryRun := &deleteDryRun{
+		removedRefs:   make(map[string]bool),
+		deletedImages: make(map[image.ID]bool),
+		layers:        make(map[layer.ChainID]layer.Layer),
+		layerRefs:     make(map[layer.ChainID]int),
+	}
+	// Layers are referenced by their child layers, by the images, and by
+	// the read-write layers of the containers.
+	for _, ls := range i.layerStores {
+		for chainID, l := range ls.Map() {
+			dryRun.layers[chainID] = l
+			if parent := l.Parent(); parent != nil {
+				dryRun.layerRefs[parent.ChainID()]++
+			}
+		}
+	}
+	images := i.imageStore.Map()
+	for _, img := range images {
+		if chainID := img.RootFS.ChainID(); chainID != "" {
+			dryRun.layerRefs[chainID]++
+		}
+	}
+	for _, c := range i.containers.List() {
+		if img, ok := images[c.ImageID]; ok {
+			if chainID := img.RootFS.ChainID(); chainID != "" {
+				dryRun.layerRefs[chainID]++
+			}
+		}
+	}
+	return dryRun
+}
+
+// isDeleted returns whether the image, or the reference to it, was removed
+// by the dry run.
+func (dryRun *deleteDryRun) isDeleted(imageRef string, imgID image.ID) bool {
+	if dryRun.deletedImages[imgID] {
+		return true
+	}
+	if ref, err := reference.ParseNormalizedNamed(imageRef); err == nil {
+		return dryRun.removedRefs[reference.TagNameOnly(ref).String()]
+	}
+	return false
+}
+
+// deleteImage records the deletion of the image, and returns the layers
+// which would be removed by releasing the layer of the image.
+func (dryRun *deleteDryRun) deleteImage(imgID image.ID, chainID layer.ChainID) []layer.Metadata {
+	dryRun.deletedImages[imgID] = true
+
+	var removed []layer.Metadata
+	for chainID != "" {
+		dryRun.layerRefs[chainID]--
+		if dryRun.layerRefs[chainID] > 0 {
+			break
+		}
+		removed = append(removed, layer.Metadata{ChainID: chainID})
+		l, ok := dryRun.layers[chainID]
+		if !ok || l.Parent() == nil {
+			break
+		}
+		chainID = l.Parent().ChainID()
+	}
+	return removed
 }
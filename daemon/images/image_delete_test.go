@@ -0,0 +1,76 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type fakeLayer struct {
+	layer.Layer
+	chainID layer.ChainID
+	parent  *fakeLayer
+	size    int64
+}
+
+func (l *fakeLayer) ChainID() layer.ChainID {
+	return l.chainID
+}
+
+func (l *fakeLayer) DiffSize() (int64, error) {
+	return l.size, nil
+}
+
+func (l *fakeLayer) Parent() layer.Layer {
+	if l.parent == nil {
+		return nil
+	}
+	return l.parent
+}
+
+func TestDeleteDryRunReleasesLayers(t *testing.T) {
+	base := &fakeLayer{chainID: "base"}
+	shared := &fakeLayer{chainID: "shared", parent: base}
+	top1 := &fakeLayer{chainID: "top1", parent: shared}
+	top2 := &fakeLayer{chainID: "top2", parent: shared}
+
+	dryRun := &deleteDryRun{
+		removedRefs:   make(map[string]bool),
+		deletedImages: make(map[image.ID]bool),
+		layers:        make(map[layer.ChainID]layer.Layer),
+		// Each layer is referenced by its children and by one image, except
+		// "base" which is only referenced by "shared".
+		layerRefs: map[layer.ChainID]int{
+			"base":   1,
+			"shared": 3,
+			"top1":   1,
+			"top2":   1,
+		},
+	}
+	for _, l := range []*fakeLayer{base, shared, top1, top2} {
+		dryRun.layers[l.chainID] = l
+	}
+
+	removed := dryRun.deleteImage("image1", "top1")
+	assert.Check(t, is.DeepEqual([]layer.Metadata{{ChainID: "top1"}}, removed))
+	assert.Check(t, dryRun.deletedImages["image1"])
+
+	removed = dryRun.deleteImage("shared-image", "shared")
+	assert.Check(t, is.Len(removed, 0))
+
+	removed = dryRun.deleteImage("image2", "top2")
+	assert.Check(t, is.DeepEqual([]layer.Metadata{{ChainID: "top2"}, {ChainID: "shared"}, {ChainID: "base"}}, removed))
+}
+
+func TestDeleteDryRunIsDeleted(t *testing.T) {
+	dryRun := &deleteDryRun{
+		removedRefs:   map[string]bool{"docker.io/library/busybox:latest": true},
+		deletedImages: map[image.ID]bool{"sha256:abcd": true},
+	}
+	assert.Check(t, dryRun.isDeleted("busybox", "sha256:1234"))
+	assert.Check(t, !dryRun.isDeleted("busybox:1.0", "sha256:1234"))
+	assert.Check(t, dryRun.isDeleted("sha256:abcd", "sha256:abcd"))
+}
@@ -0,0 +1,141 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// GCPolicy is the policy of the garbage collection of unused images.
+type GCPolicy struct {
+	// ReclaimSpace is the amount of space to reclaim by removing the least
+	// recently used images. Zero means that images are not removed to
+	// reclaim space.
+	ReclaimSpace int64
+	// MaxAge is the time after which unused images are removed, whatever
+	// the space to reclaim. Zero means that images never expire.
+	MaxAge time.Duration
+	// MinAge is the time during which images are kept after they have
+	// been used, whatever the space to reclaim.
+	MinAge time.Duration
+}
+
+type gcCandidate struct {
+	id       image.ID
+	lastUsed time.Time
+}
+
+// ImagesGC removes the least recently used images which are not used by any
+// container, according to the policy. Images are removed the same way as
+// with ImagesPrune, so that an event is logged for each removal.
+func (i *ImageService) ImagesGC(ctx context.Context, policy GCPolicy) (*types.ImagesPruneReport, error) {
+	if !atomic.CompareAndSwapInt32(&i.pruneRunning, 0, 1) {
+		return nil, errPruneRunning
+	}
+	defer atomic.StoreInt32(&i.pruneRunning, 0)
+
+	allLayers := make(map[layer.ChainID]layer.Layer)
+	for _, ls := range i.layerStores {
+		for k, v := range ls.Map() {
+			allLayers[k] = v
+		}
+	}
+
+	// Images used by a container, running or stopped, are never candidates:
+	// ImageDelete only refuses to remove the last reference of such an
+	// image, and would untag it otherwise.
+	usedImages := make(map[image.ID]bool)
+	for _, c := range i.containers.List() {
+		usedImages[c.ImageID] = true
+	}
+
+	var candidates []gcCandidate
+	for id, img := range i.imageStore.Map() {
+		if usedImages[id] {
+			continue
+		}
+		// Skip intermediate images, they are removed with their children.
+		if len(i.referenceStore.References(digest.Digest(id))) == 0 && len(i.imageStore.Children(id)) != 0 {
+			continue
+		}
+		candidates = append(candidates, gcCandidate{id: id, lastUsed: i.imageLastUsed(id, img)})
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		return candidates[a].lastUsed.Before(candidates[b].lastUsed)
+	})
+
+	now := time.Now()
+	rep := &types.ImagesPruneReport{}
+	for _, c := range candidates {
+		select {
+		case <-ctx.Done():
+			return rep, ctx.Err()
+		default:
+		}
+
+		if now.Sub(c.lastUsed) < policy.MinAge {
+			// Candidates are sorted, the remaining ones are more recent.
+			break
+		}
+		expired := policy.MaxAge > 0 && now.Sub(c.lastUsed) > policy.MaxAge
+		if !expired && int64(rep.SpaceReclaimed) >= policy.ReclaimSpace {
+			break
+		}
+
+		var refs []string
+		for _, ref := range i.referenceStore.References(c.id.Digest()) {
+			refs = append(refs, ref.String())
+		}
+		if len(refs) == 0 {
+			refs = append(refs, c.id.Digest().Hex())
+		}
+
+		var deleted []types.ImageDeleteResponseItem
+		for _, ref := range refs {
+			imgDel, err := i.ImageDelete(ref, false, true)
+			if imageDeleteFailed(ref, err) {
+				// The image is used by a container.
+				break
+			}
+			deleted = append(deleted, imgDel...)
+		}
+
+		for _, d := range deleted {
+			if d.Deleted == "" {
+				continue
+			}
+			if l, ok := allLayers[layer.ChainID(d.Deleted)]; ok {
+				diffSize, err := l.DiffSize()
+				if err != nil {
+					logrus.Warnf("failed to get layer %s size: %v", d.Deleted, err)
+					continue
+				}
+				rep.SpaceReclaimed += uint64(diffSize)
+			}
+		}
+		rep.ImagesDeleted = append(rep.ImagesDeleted, deleted...)
+	}
+
+	return rep, nil
+}
+
+// imageLastUsed returns the last time an image was used, which is the last
+// time it was used to create a container or by a build, pulled, tagged or
+// loaded, or its creation time.
+func (i *ImageService) imageLastUsed(id image.ID, img *image.Image) time.Time {
+	lastUsed := img.Created
+	if t, err := i.imageStore.GetLastUpdated(id); err == nil && t.After(lastUsed) {
+		lastUsed = t
+	}
+	if t, err := i.imageStore.GetLastUsed(id); err == nil && t.After(lastUsed) {
+		lastUsed = t
+	}
+	return lastUsed
+}
@@ -0,0 +1,182 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	dockerreference "github.com/docker/docker/reference"
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type fakeLayerStore struct {
+	layer.Store
+	layers map[layer.ChainID]layer.Layer
+}
+
+func (s *fakeLayerStore) Map() map[layer.ChainID]layer.Layer {
+	return s.layers
+}
+
+func (s *fakeLayerStore) Get(id layer.ChainID) (layer.Layer, error) {
+	l, ok := s.layers[id]
+	if !ok {
+		return nil, layer.ErrLayerDoesNotExist
+	}
+	return l, nil
+}
+
+func (s *fakeLayerStore) Release(l layer.Layer) ([]layer.Metadata, error) {
+	delete(s.layers, l.ChainID())
+	return []layer.Metadata{{ChainID: l.ChainID()}}, nil
+}
+
+func newGCTestService(t *testing.T) (*ImageService, *fakeLayerStore, func()) {
+	root, err := ioutil.TempDir("", "images-gc-test")
+	assert.NilError(t, err)
+
+	ls := &fakeLayerStore{layers: make(map[layer.ChainID]layer.Layer)}
+	fs, err := image.NewFSStoreBackend(filepath.Join(root, "imagedb"))
+	assert.NilError(t, err)
+	imageStore, err := image.NewImageStore(fs, map[string]image.LayerGetReleaser{runtime.GOOS: ls})
+	assert.NilError(t, err)
+	referenceStore, err := dockerreference.NewReferenceStore(filepath.Join(root, "repositories.json"))
+	assert.NilError(t, err)
+
+	i := &ImageService{
+		containers:     container.NewMemoryStore(),
+		eventsService:  daemonevents.New(),
+		imageStore:     imageStore,
+		layerStores:    map[string]layer.Store{runtime.GOOS: ls},
+		referenceStore: referenceStore,
+	}
+	return i, ls, func() { os.RemoveAll(root) }
+}
+
+// createImage creates an image with a single layer of the given size, last
+// used at the given time, and tags it with each of the given references.
+func createImage(t *testing.T, i *ImageService, ls *fakeLayerStore, size int64, lastUsed time.Time, refs ...string) image.ID {
+	diffID := layer.DiffID(digest.FromString(refs[0]))
+	ls.layers[layer.ChainID(diffID)] = &fakeLayer{chainID: layer.ChainID(diffID), size: size}
+
+	config, err := json.Marshal(&image.Image{
+		V1Image: image.V1Image{
+			Created: lastUsed,
+			OS:      runtime.GOOS,
+		},
+		RootFS: &image.RootFS{Type: "layers", DiffIDs: []layer.DiffID{diffID}},
+	})
+	assert.NilError(t, err)
+	id, err := i.imageStore.Create(config)
+	assert.NilError(t, err)
+
+	for _, r := range refs {
+		named, err := reference.ParseNormalizedNamed(r)
+		assert.NilError(t, err)
+		assert.NilError(t, i.referenceStore.AddTag(named, id.Digest(), false))
+	}
+	return id
+}
+
+func untagged(items []types.ImageDeleteResponseItem) []string {
+	var refs []string
+	for _, item := range items {
+		if item.Untagged != "" {
+			refs = append(refs, item.Untagged)
+		}
+	}
+	return refs
+}
+
+func imageExists(i *ImageService, id image.ID) bool {
+	_, err := i.imageStore.Get(id)
+	return err == nil
+}
+
+func TestImagesGCEvictionOrder(t *testing.T) {
+	i, ls, cleanup := newGCTestService(t)
+	defer cleanup()
+
+	now := time.Now()
+	recent := createImage(t, i, ls, 10, now.Add(-1*time.Hour), "recent:latest")
+	oldest := createImage(t, i, ls, 10, now.Add(-3*time.Hour), "oldest:latest")
+	older := createImage(t, i, ls, 10, now.Add(-2*time.Hour), "older:latest")
+
+	rep, err := i.ImagesGC(context.Background(), GCPolicy{ReclaimSpace: 15})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(uint64(20), rep.SpaceReclaimed))
+
+	// The least recently used images are removed first, until enough space
+	// is reclaimed.
+	assert.Check(t, is.DeepEqual([]string{"oldest:latest", "older:latest"}, untagged(rep.ImagesDeleted)))
+	assert.Check(t, !imageExists(i, oldest))
+	assert.Check(t, !imageExists(i, older))
+	assert.Check(t, imageExists(i, recent))
+}
+
+func TestImagesGCMinAge(t *testing.T) {
+	i, ls, cleanup := newGCTestService(t)
+	defer cleanup()
+
+	now := time.Now()
+	recent := createImage(t, i, ls, 10, now.Add(-1*time.Hour), "recent:latest")
+	old := createImage(t, i, ls, 10, now.Add(-3*time.Hour), "old:latest")
+
+	// Images used more recently than MinAge are kept, even if not enough
+	// space was reclaimed.
+	rep, err := i.ImagesGC(context.Background(), GCPolicy{ReclaimSpace: 100, MinAge: 2 * time.Hour})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(uint64(10), rep.SpaceReclaimed))
+	assert.Check(t, !imageExists(i, old))
+	assert.Check(t, imageExists(i, recent))
+}
+
+func TestImagesGCMaxAge(t *testing.T) {
+	i, ls, cleanup := newGCTestService(t)
+	defer cleanup()
+
+	now := time.Now()
+	recent := createImage(t, i, ls, 10, now.Add(-1*time.Hour), "recent:latest")
+	expired := createImage(t, i, ls, 10, now.Add(-3*time.Hour), "expired:latest")
+
+	// Images unused for longer than MaxAge are removed, even without any
+	// space to reclaim.
+	rep, err := i.ImagesGC(context.Background(), GCPolicy{MaxAge: 2 * time.Hour})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(uint64(10), rep.SpaceReclaimed))
+	assert.Check(t, !imageExists(i, expired))
+	assert.Check(t, imageExists(i, recent))
+}
+
+func TestImagesGCSkipsImagesUsedByContainers(t *testing.T) {
+	i, ls, cleanup := newGCTestService(t)
+	defer cleanup()
+
+	now := time.Now()
+	used := createImage(t, i, ls, 10, now.Add(-3*time.Hour), "used:latest", "used:1.0")
+	unused := createImage(t, i, ls, 10, now.Add(-2*time.Hour), "unused:latest")
+	// A stopped container still uses its image.
+	containers := container.NewMemoryStore()
+	containers.Add("stopped", &container.Container{ID: "stopped", ImageID: used, State: container.NewState()})
+	i.containers = containers
+
+	rep, err := i.ImagesGC(context.Background(), GCPolicy{ReclaimSpace: 100})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"unused:latest"}, untagged(rep.ImagesDeleted)))
+	assert.Check(t, imageExists(i, used))
+	assert.Check(t, is.Len(i.referenceStore.References(used.Digest()), 2))
+	assert.Check(t, !imageExists(i, unused))
+}
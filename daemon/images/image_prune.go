@@ -19,18 +19,20 @@ import (
 )
 
 var imagesAcceptedFilters = map[string]bool{
-	"dangling": true,
-	"label":    true,
-	"label!":   true,
-	"until":    true,
+	"dangling":   true,
+	"label":      true,
+	"label!":     true,
+	"until":      true,
+	"unused-for": true,
 }
 
 // errPruneRunning is returned when a prune request is received while
 // one is in progress
 var errPruneRunning = errdefs.Conflict(errors.New("a prune operation is already running"))
 
-// ImagesPrune removes unused images
-func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Args) (*types.ImagesPruneReport, error) {
+// ImagesPrune removes unused images. If dryRun is true, nothing is removed,
+// and the report of what would be removed is returned.
+func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (*types.ImagesPruneReport, error) {
 	if !atomic.CompareAndSwapInt32(&i.pruneRunning, 0, 1) {
 		return nil, errPruneRunning
 	}
@@ -58,6 +60,11 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 		return nil, err
 	}
 
+	unusedSince, err := getUnusedSinceFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	var allImages map[image.ID]*image.Image
 	if danglingOnly {
 		allImages = i.imageStore.Heads()
@@ -85,6 +92,9 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 			if !until.IsZero() && img.Created.After(until) {
 				continue
 			}
+			if !unusedSince.IsZero() && i.imageLastUsed(id, img).After(unusedSince) {
+				continue
+			}
 			if img.Config != nil && !matchLabels(pruneFilters, img.Config.Labels) {
 				continue
 			}
@@ -92,6 +102,11 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 		}
 	}
 
+	var dr *deleteDryRun
+	if dryRun {
+		dr = i.newDeleteDryRun()
+	}
+
 	canceled := false
 deleteImagesLoop:
 	for id := range topImages {
@@ -104,7 +119,7 @@ deleteImagesLoop:
 		}
 
 		deletedImages := []types.ImageDeleteResponseItem{}
-		refs := i.referenceStore.References(id.Digest())
+		refs := i.references(id, dr)
 		if len(refs) > 0 {
 			shouldDelete := !danglingOnly
 			if !shouldDelete {
@@ -122,7 +137,11 @@ deleteImagesLoop:
 
 			if shouldDelete {
 				for _, ref := range refs {
-					imgDel, err := i.ImageDelete(ref.String(), false, true)
+					if dr != nil && dr.isDeleted(ref.String(), id) {
+						// Removed along with a previous reference.
+						continue
+					}
+					imgDel, err := i.imageDelete(ref.String(), false, true, dr)
 					if imageDeleteFailed(ref.String(), err) {
 						continue
 					}
@@ -131,7 +150,11 @@ deleteImagesLoop:
 			}
 		} else {
 			hex := id.Digest().Hex()
-			imgDel, err := i.ImageDelete(hex, false, true)
+			if dr != nil && dr.deletedImages[id] {
+				// Deleted as the parent of a previous image.
+				continue
+			}
+			imgDel, err := i.imageDelete(hex, false, true, dr)
 			if imageDeleteFailed(hex, err) {
 				continue
 			}
@@ -209,3 +232,20 @@ func getUntilFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
 	until = time.Unix(seconds, nanoseconds)
 	return until, nil
 }
+
+// getUnusedSinceFromPruneFilters returns the time after which images must
+// not have been used to be pruned, from the "unused-for" duration filter.
+func getUnusedSinceFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
+	if !pruneFilters.Contains("unused-for") {
+		return time.Time{}, nil
+	}
+	unusedFilters := pruneFilters.Get("unused-for")
+	if len(unusedFilters) > 1 {
+		return time.Time{}, fmt.Errorf("more than one unused-for filter specified")
+	}
+	d, err := time.ParseDuration(unusedFilters[0])
+	if err != nil || d < 0 {
+		return time.Time{}, invalidFilter{"unused-for", unusedFilters[0]}
+	}
+	return time.Now().Add(-d), nil
+}
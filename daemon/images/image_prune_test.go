@@ -0,0 +1,30 @@
+package images // import "github.com/docker/docker/daemon/images"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestGetUnusedSinceFromPruneFilters(t *testing.T) {
+	unusedSince, err := getUnusedSinceFromPruneFilters(filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Check(t, unusedSince.IsZero())
+
+	before := time.Now()
+	unusedSince, err = getUnusedSinceFromPruneFilters(filters.NewArgs(filters.Arg("unused-for", "720h")))
+	assert.NilError(t, err)
+	assert.Check(t, !unusedSince.Before(before.Add(-720*time.Hour)))
+	assert.Check(t, !unusedSince.After(time.Now().Add(-720*time.Hour)))
+
+	_, err = getUnusedSinceFromPruneFilters(filters.NewArgs(filters.Arg("unused-for", "1h"), filters.Arg("unused-for", "2h")))
+	assert.Check(t, is.ErrorContains(err, "more than one unused-for filter specified"))
+
+	for _, v := range []string{"30", "-1h", "2018-01-01"} {
+		_, err = getUnusedSinceFromPruneFilters(filters.NewArgs(filters.Arg("unused-for", v)))
+		assert.Check(t, is.ErrorContains(err, "Invalid filter 'unused-for="+v+"'"), v)
+	}
+}
@@ -10,12 +10,27 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution"
 	progressutils "github.com/docker/docker/distribution/utils"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
+	"github.com/pkg/errors"
 )
 
 // PushImage initiates a push operation on the repository named localName.
-func (i *ImageService) PushImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// compression is the compression of the uploaded layers, "gzip" (the
+// default) or "zstd".
+func (i *ImageService) PushImage(ctx context.Context, image, tag, compression string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
 	start := time.Now()
+	var layerCompression archive.Compression
+	switch compression {
+	case "", "gzip":
+		layerCompression = archive.Gzip
+	case "zstd":
+		layerCompression = archive.Zstd
+	default:
+		return errdefs.InvalidParameter(errors.Errorf("unsupported layer compression: %s", compression))
+	}
+
 	ref, err := reference.ParseNormalizedNamed(image)
 	if err != nil {
 		return err
@@ -52,10 +67,11 @@ func (i *ImageService) PushImage(ctx context.Context, image, tag string, metaHea
 			ImageStore:       distribution.NewImageConfigStoreFromStore(i.imageStore),
 			ReferenceStore:   i.referenceStore,
 		},
-		ConfigMediaType: schema2.MediaTypeImageConfig,
-		LayerStores:     distribution.NewLayerProvidersFromStores(i.layerStores),
-		TrustKey:        i.trustKey,
-		UploadManager:   i.uploadManager,
+		ConfigMediaType:  schema2.MediaTypeImageConfig,
+		LayerStores:      distribution.NewLayerProvidersFromStores(i.layerStores),
+		TrustKey:         i.trustKey,
+		UploadManager:    i.uploadManager,
+		LayerCompression: layerCompression,
 	}
 
 	err = distribution.Push(ctx, ref, imagePushConfig)
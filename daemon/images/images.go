@@ -142,6 +142,9 @@ func (i *ImageService) Images(imageFilters filters.Args, all bool, withExtraAttr
 		}
 
 		newImage := newImage(img, size)
+		if lastUsed, err := i.imageStore.GetLastUsed(id); err == nil && !lastUsed.IsZero() {
+			newImage.LastUsed = lastUsed.Unix()
+		}
 
 		for _, ref := range i.referenceStore.References(id.Digest()) {
 			if imageFilters.Contains("reference") {
@@ -109,6 +109,15 @@ func (i *ImageService) Children(id image.ID) []image.ID {
 	return i.imageStore.Children(id)
 }
 
+// SetImageLastUsed records that an image was used to create a container or
+// by a build.
+// called from create.go
+func (i *ImageService) SetImageLastUsed(id image.ID) {
+	if err := i.imageStore.SetLastUsed(id); err != nil {
+		logrus.Warnf("failed to set last used time of image %s: %v", id, err)
+	}
+}
+
 // CreateLayer creates a filesystem layer for a container.
 // called from create.go
 // TODO: accept an opt struct instead of container?
@@ -11,6 +11,7 @@ import (
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/api/types/versions/v1p20"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
@@ -159,6 +160,8 @@ func (daemon *Daemon) getInspectData(container *container.Container) (*types.Con
 		StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
 		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
 		Health:     containerHealth,
+
+		CrashLooping: container.State.CrashLooping,
 	}
 
 	contJSONBase := &types.ContainerJSONBase{
@@ -218,13 +221,15 @@ func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, err
 		return nil, errExecNotFound(id)
 	}
 
-	pc := inspectExecProcessConfig(e)
+	return execInspect(e), nil
+}
 
+func execInspect(e *exec.Config) *backend.ExecInspect {
 	return &backend.ExecInspect{
 		ID:            e.ID,
 		Running:       e.Running,
 		ExitCode:      e.ExitCode,
-		ProcessConfig: pc,
+		ProcessConfig: inspectExecProcessConfig(e),
 		OpenStdin:     e.OpenStdin,
 		OpenStdout:    e.OpenStdout,
 		OpenStderr:    e.OpenStderr,
@@ -232,7 +237,8 @@ func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, err
 		ContainerID:   e.ContainerID,
 		DetachKeys:    e.DetachKeys,
 		Pid:           e.Pid,
-	}, nil
+		Timeout:       e.Timeout,
+	}
 }
 
 func (daemon *Daemon) getBackwardsCompatibleNetworkSettings(settings *network.Settings) *v1p20.NetworkSettings {
@@ -124,6 +124,11 @@ func (a *pluginAdapterWithRead) ReadLogs(config ReadConfig) *LogWatcher {
 			if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
 				return
 			}
+			// The plugin applies the tail itself, before this filter: the
+			// stream gives no way to tell where the backlog ends.
+			if !config.Filter.Match(msg) {
+				continue
+			}
 
 			select {
 			case watcher.Msg <- msg:
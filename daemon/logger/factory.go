@@ -19,9 +19,10 @@ type Creator func(Info) (Logger, error)
 type LogOptValidator func(cfg map[string]string) error
 
 type logdriverFactory struct {
-	registry     map[string]Creator
-	optValidator map[string]LogOptValidator
-	m            sync.Mutex
+	registry           map[string]Creator
+	optValidator       map[string]LogOptValidator
+	externalValidators []LogOptValidator
+	m                  sync.Mutex
 }
 
 func (lf *logdriverFactory) list() []string {
@@ -76,6 +77,21 @@ func (lf *logdriverFactory) registerLogOptValidator(name string, l LogOptValidat
 	return nil
 }
 
+func (lf *logdriverFactory) registerExternalValidator(v LogOptValidator) {
+	lf.m.Lock()
+	lf.externalValidators = append(lf.externalValidators, v)
+	lf.m.Unlock()
+}
+
+func (lf *logdriverFactory) getExternalValidators() []LogOptValidator {
+	lf.m.Lock()
+	defer lf.m.Unlock()
+
+	validators := make([]LogOptValidator, len(lf.externalValidators))
+	copy(validators, lf.externalValidators)
+	return validators
+}
+
 func (lf *logdriverFactory) get(name string) (Creator, error) {
 	lf.m.Lock()
 	defer lf.m.Unlock()
@@ -116,11 +132,28 @@ func GetLogDriver(name string) (Creator, error) {
 	return factory.get(name)
 }
 
+// RegisterExternalValidator adds a validator to be called in addition to the
+// log driver specific validator. External validators are called with the
+// full set of log options, including the built-in ones, for every driver.
+func RegisterExternalValidator(v LogOptValidator) {
+	factory.registerExternalValidator(v)
+}
+
 var builtInLogOpts = map[string]bool{
 	"mode":            true,
 	"max-buffer-size": true,
 }
 
+// AddBuiltinLogOpts updates the list of built-in log opts. These options are
+// accepted for every log driver and are not passed to the driver specific
+// validator.
+// This should only be called from package initialization.
+func AddBuiltinLogOpts(opts map[string]bool) {
+	for k, v := range opts {
+		builtInLogOpts[k] = v
+	}
+}
+
 // ValidateLogOpts checks the options for the given log driver. The
 // options supported are specific to the LogDriver implementation.
 func ValidateLogOpts(name string, cfg map[string]string) error {
@@ -147,6 +180,12 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
 	}
 
+	for _, validator := range factory.getExternalValidators() {
+		if err := validator(cfg); err != nil {
+			return err
+		}
+	}
+
 	filteredOpts := make(map[string]string, len(builtInLogOpts))
 	for k, v := range cfg {
 		if !builtInLogOpts[k] {
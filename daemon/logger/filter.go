@@ -0,0 +1,64 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// MessageFilter selects log messages by stream, content and attributes.
+// Log readers apply the filter before sending messages to the LogWatcher so
+// that non-matching messages never leave the daemon.
+type MessageFilter struct {
+	// Sources, if not empty, is the list of streams (such as "stdout" or
+	// "stderr") that messages must come from.
+	Sources []string
+	// Pattern, if set, must match the message line (without the trailing
+	// newline).
+	Pattern *regexp.Regexp
+	// Attrs is a list of "key" or "key=value" pairs that must all be present
+	// in the attributes of the message.
+	Attrs []string
+}
+
+// Match reports whether msg satisfies all the conditions of the filter.
+// A nil filter matches every message.
+func (f *MessageFilter) Match(msg *Message) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Sources) > 0 && !f.matchSource(msg.Source) {
+		return false
+	}
+	if f.Pattern != nil && !f.Pattern.Match(bytes.TrimSuffix(msg.Line, []byte{'\n'})) {
+		return false
+	}
+	for _, attr := range f.Attrs {
+		if !matchAttr(msg, attr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *MessageFilter) matchSource(source string) bool {
+	for _, s := range f.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAttr(msg *Message, attr string) bool {
+	kv := strings.SplitN(attr, "=", 2)
+	for _, a := range msg.Attrs {
+		if a.Key != kv[0] {
+			continue
+		}
+		if len(kv) == 1 || a.Value == kv[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package logger // import "github.com/docker/docker/daemon/logger"
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/docker/docker/api/types/backend"
+	"gotest.tools/assert"
+)
+
+func TestMessageFilterMatch(t *testing.T) {
+	msg := &Message{
+		Source: "stderr",
+		Line:   []byte("connection refused\n"),
+		Attrs:  []backend.LogAttr{{Key: "tag", Value: "web"}, {Key: "env", Value: "prod"}},
+	}
+
+	testCases := []struct {
+		filter *MessageFilter
+		match  bool
+	}{
+		{filter: nil, match: true},
+		{filter: &MessageFilter{}, match: true},
+		{filter: &MessageFilter{Sources: []string{"stdout", "stderr"}}, match: true},
+		{filter: &MessageFilter{Sources: []string{"stdout"}}, match: false},
+		{filter: &MessageFilter{Pattern: regexp.MustCompile("refused$")}, match: true},
+		{filter: &MessageFilter{Pattern: regexp.MustCompile("^timeout")}, match: false},
+		{filter: &MessageFilter{Attrs: []string{"tag=web", "env"}}, match: true},
+		{filter: &MessageFilter{Attrs: []string{"tag=web", "env=dev"}}, match: false},
+		{filter: &MessageFilter{Attrs: []string{"missing"}}, match: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.filter.Match(msg), tc.match, "filter: %+v", tc.filter)
+	}
+}
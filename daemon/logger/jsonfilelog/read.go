@@ -1,12 +1,14 @@
 package jsonfilelog // import "github.com/docker/docker/daemon/logger/jsonfilelog"
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog/jsonlog"
+	"github.com/docker/docker/pkg/tailfile"
 )
 
 const maxJSONDecodeRetry = 20000
@@ -87,3 +89,13 @@ func decodeFunc(rdr io.Reader) func() (*logger.Message, error) {
 		return msg, err
 	}
 }
+
+// getTailReader returns a reader containing only the last nLogLines
+// newline-delimited JSON entries of the passed in reader.
+func getTailReader(r io.ReadSeeker, nLogLines int) (io.Reader, error) {
+	ls, err := tailfile.TailFile(r, nLogLines)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(bytes.Join(ls, []byte("\n"))), nil
+}
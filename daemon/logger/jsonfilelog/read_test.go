@@ -2,6 +2,7 @@ package jsonfilelog // import "github.com/docker/docker/daemon/logger/jsonfilelo
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -62,3 +63,58 @@ func BenchmarkJSONFileLoggerReadLogs(b *testing.B) {
 		}
 	}
 }
+
+func TestReadLogsTailWithFilter(t *testing.T) {
+	tmp := fs.NewDir(t, t.Name())
+	defer tmp.Remove()
+
+	l, err := New(logger.Info{
+		ContainerID: "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657",
+		LogPath:     tmp.Join("container.log"),
+	})
+	assert.NilError(t, err)
+	defer l.Close()
+
+	for i, source := range []string{"stderr", "stdout", "stderr", "stderr", "stdout", "stdout"} {
+		msg := &logger.Message{
+			Line:      []byte(fmt.Sprintf("line%d", i)),
+			Source:    source,
+			Timestamp: time.Now().UTC(),
+		}
+		assert.NilError(t, l.Log(msg))
+	}
+
+	readLines := func(config logger.ReadConfig) []string {
+		lw := l.(*JSONFileLogger).ReadLogs(config)
+		defer lw.Close()
+
+		var lines []string
+		for {
+			select {
+			case msg, ok := <-lw.Msg:
+				if !ok {
+					return lines
+				}
+				lines = append(lines, string(msg.Line))
+			case err := <-lw.Err:
+				t.Fatal(err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("timeout waiting for log messages")
+			}
+		}
+	}
+
+	// The last lines don't match, so the file is read further backwards.
+	lines := readLines(logger.ReadConfig{
+		Tail:   2,
+		Filter: &logger.MessageFilter{Sources: []string{"stderr"}},
+	})
+	assert.DeepEqual(t, lines, []string{"line2\n", "line3\n"})
+
+	// Fewer lines than the tail match.
+	lines = readLines(logger.ReadConfig{
+		Tail:   5,
+		Filter: &logger.MessageFilter{Sources: []string{"stderr"}},
+	})
+	assert.DeepEqual(t, lines, []string{"line0\n", "line2\n", "line3\n"})
+}
@@ -0,0 +1,193 @@
+package local // import "github.com/docker/docker/daemon/logger/local"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestWriteLog(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+
+	logPath := filepath.Join(dir, "test.log")
+
+	l, err := New(logger.Info{LogPath: logPath})
+	assert.Assert(t, err)
+	defer l.Close()
+
+	m1 := logger.Message{Source: "stdout", Timestamp: time.Now().Add(-1 * 30 * time.Minute), Line: []byte("message 1")}
+	m2 := logger.Message{Source: "stdout", Timestamp: time.Now().Add(-1 * 20 * time.Minute), Line: []byte("message 2"), PLogMetaData: &backend.PartialLogMetaData{Last: true, ID: "0001", Ordinal: 1}}
+	m3 := logger.Message{Source: "stderr", Timestamp: time.Now().Add(-1 * 10 * time.Minute), Line: []byte("message 3")}
+
+	// copy the log message because the underlying log writer resets the log message and returns it to a buffer pool
+	err = l.Log(copyLogMessage(&m1))
+	assert.Assert(t, err)
+	err = l.Log(copyLogMessage(&m2))
+	assert.Assert(t, err)
+	err = l.Log(copyLogMessage(&m3))
+	assert.Assert(t, err)
+
+	f, err := os.Open(logPath)
+	assert.Assert(t, err)
+	defer f.Close()
+	dec := logdriver.NewLogEntryDecoder(f)
+
+	var proto logdriver.LogEntry
+	for _, m := range []logger.Message{m1, m2, m3} {
+		err = dec.Decode(&proto)
+		assert.Assert(t, err)
+		assert.Check(t, is.Equal(string(m.Line), string(proto.Line)))
+		assert.Check(t, is.Equal(m.Source, proto.Source))
+		assert.Check(t, is.Equal(m.Timestamp.UnixNano(), proto.TimeNano))
+
+		// skip the size footer of the entry
+		var footer uint32
+		assert.Assert(t, binary.Read(f, binary.BigEndian, &footer))
+		assert.Check(t, is.Equal(int(footer), proto.Size()))
+	}
+}
+
+func TestReadLog(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+
+	logPath := filepath.Join(dir, "test.log")
+	l, err := New(logger.Info{LogPath: logPath})
+	assert.Assert(t, err)
+	defer l.Close()
+
+	m1 := logger.Message{Source: "stdout", Timestamp: time.Now().Add(-1 * 30 * time.Minute), Line: []byte("a message")}
+	m2 := logger.Message{Source: "stdout", Timestamp: time.Now().Add(-1 * 20 * time.Minute), Line: []byte("another message"), PLogMetaData: &backend.PartialLogMetaData{Ordinal: 1, Last: true}}
+	longMessage := []byte("a really long message " + string(bytes.Repeat([]byte("a"), initialBufSize*2)))
+	m3 := logger.Message{Source: "stderr", Timestamp: time.Now().Add(-1 * 10 * time.Minute), Line: longMessage}
+	m4 := logger.Message{Source: "stderr", Timestamp: time.Now().Add(-1 * 10 * time.Minute), Line: []byte("just one more message")}
+
+	// copy the log message because the underlying log writer resets the log message and returns it to a buffer pool
+	err = l.Log(copyLogMessage(&m1))
+	assert.Assert(t, err)
+	err = l.Log(copyLogMessage(&m2))
+	assert.Assert(t, err)
+	err = l.Log(copyLogMessage(&m3))
+	assert.Assert(t, err)
+	err = l.Log(copyLogMessage(&m4))
+	assert.Assert(t, err)
+
+	lr := l.(logger.LogReader)
+
+	testMessage := func(t *testing.T, lw *logger.LogWatcher, m *logger.Message) {
+		t.Helper()
+		timer := time.NewTimer(10 * time.Second)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			t.Fatal("timeout waiting for message")
+		case err, open := <-lw.Err:
+			t.Fatalf("unexpected receive on error channel: %v, %v", err, open)
+		case msg, open := <-lw.Msg:
+			assert.Assert(t, open, "unexpected close of log message channel")
+			assert.Check(t, is.Equal(m.Source, msg.Source))
+			assert.Check(t, is.Equal(m.Timestamp.UnixNano(), msg.Timestamp.UnixNano()))
+			assert.Check(t, is.Equal(string(m.Line)+"\n", string(msg.Line)))
+		}
+	}
+
+	t.Run("tail exact", func(t *testing.T) {
+		lw := lr.ReadLogs(logger.ReadConfig{Tail: 4})
+		defer lw.Close()
+
+		testMessage(t, lw, &m1)
+		testMessage(t, lw, &m2)
+		testMessage(t, lw, &m3)
+		testMessage(t, lw, &m4)
+	})
+
+	t.Run("tail less than available", func(t *testing.T) {
+		lw := lr.ReadLogs(logger.ReadConfig{Tail: 2})
+		defer lw.Close()
+
+		testMessage(t, lw, &m3)
+		testMessage(t, lw, &m4)
+	})
+
+	t.Run("tail more than available", func(t *testing.T) {
+		lw := lr.ReadLogs(logger.ReadConfig{Tail: 100})
+		defer lw.Close()
+
+		testMessage(t, lw, &m1)
+		testMessage(t, lw, &m2)
+		testMessage(t, lw, &m3)
+		testMessage(t, lw, &m4)
+	})
+}
+
+func TestRotateAndCompressByDefault(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+
+	logPath := filepath.Join(dir, "test.log")
+	l, err := New(logger.Info{LogPath: logPath, Config: map[string]string{"max-size": "1k"}})
+	assert.Assert(t, err)
+
+	line := bytes.Repeat([]byte("a"), 512)
+	for i := 0; i < 10; i++ {
+		err = l.Log(&logger.Message{Source: "stdout", Timestamp: time.Now(), Line: append([]byte(nil), line...)})
+		assert.Assert(t, err)
+	}
+	assert.Assert(t, l.Close())
+
+	// compression happens asynchronously after rotation
+	poll := time.NewTimer(10 * time.Second)
+	defer poll.Stop()
+	for {
+		if _, err := os.Stat(logPath + ".1.gz"); err == nil {
+			break
+		}
+		select {
+		case <-poll.C:
+			t.Fatal("timeout waiting for rotated log to be compressed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestValidateLogOpt(t *testing.T) {
+	assert.Check(t, ValidateLogOpt(map[string]string{"max-size": "10m", "max-file": "3", "compress": "false"}))
+	assert.Check(t, is.ErrorContains(ValidateLogOpt(map[string]string{"labels": "foo"}), "unknown log opt"))
+
+	_, err := New(logger.Info{LogPath: "/dev/null", Config: map[string]string{"max-file": "1"}})
+	assert.Check(t, is.ErrorContains(err, "compression cannot be enabled"))
+}
+
+func copyLogMessage(src *logger.Message) *logger.Message {
+	dst := logger.NewMessage()
+	dst.Source = src.Source
+	dst.Timestamp = src.Timestamp
+	dst.Attrs = src.Attrs
+	dst.Err = src.Err
+	dst.Line = append(dst.Line, src.Line...)
+	if src.PLogMetaData != nil {
+		md := *src.PLogMetaData
+		dst.PLogMetaData = &md
+	}
+	return dst
+}
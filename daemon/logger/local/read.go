@@ -0,0 +1,141 @@
+package local // import "github.com/docker/docker/daemon/logger/local"
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/pkg/errors"
+)
+
+// maxMsgLen is the maximum size of a single encoded log entry that the
+// decoder is willing to allocate for. Anything larger is considered corrupt.
+const maxMsgLen int = 1e6 // 1MB.
+
+func (d *driver) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	logWatcher := logger.NewLogWatcher()
+
+	go d.readLogs(logWatcher, config)
+	return logWatcher
+}
+
+func (d *driver) readLogs(watcher *logger.LogWatcher, config logger.ReadConfig) {
+	defer close(watcher.Msg)
+
+	d.mu.Lock()
+	d.readers[watcher] = struct{}{}
+	d.mu.Unlock()
+
+	d.logfile.ReadLogs(config, watcher)
+
+	d.mu.Lock()
+	delete(d.readers, watcher)
+	d.mu.Unlock()
+}
+
+// getTailReader walks backwards through the passed in reader using the size
+// footer of each entry and returns the reader positioned at the start of the
+// oldest of the last req entries.
+func getTailReader(r io.ReadSeeker, req int) (io.Reader, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting log size")
+	}
+
+	offset := size
+	buf := make([]byte, encodeBinaryLen)
+	for found := 0; found < req && offset > 0; found++ {
+		if offset < 2*encodeBinaryLen {
+			return nil, errors.New("log file is corrupted: truncated log entry")
+		}
+		if _, err := r.Seek(offset-encodeBinaryLen, io.SeekStart); err != nil {
+			return nil, errors.Wrap(err, "error seeking to log entry footer")
+		}
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, errors.Wrap(err, "error reading log entry footer")
+		}
+
+		msgLen := int64(binary.BigEndian.Uint32(buf))
+		offset -= msgLen + 2*encodeBinaryLen
+		if offset < 0 {
+			return nil, errors.New("log file is corrupted: log entry size exceeds file size")
+		}
+	}
+
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "error seeking to first tailed log entry")
+	}
+	return r, nil
+}
+
+// readFull fills buf from rdr, retrying short reads that happen when the
+// reader catches up with a log entry that is still being written.
+func readFull(rdr io.Reader, buf []byte) error {
+	var read int
+	for i := 0; i < maxDecodeRetry; i++ {
+		n, err := io.ReadFull(rdr, buf[read:])
+		read += n
+		if err == nil {
+			return nil
+		}
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+	}
+	return io.ErrUnexpectedEOF
+}
+
+func decodeFunc(rdr io.Reader) func() (*logger.Message, error) {
+	proto := &logdriver.LogEntry{}
+	buf := make([]byte, initialBufSize)
+
+	return func() (*logger.Message, error) {
+		resetProto(proto)
+
+		n, err := io.ReadFull(rdr, buf[:encodeBinaryLen])
+		if err == io.ErrUnexpectedEOF {
+			err = readFull(rdr, buf[n:encodeBinaryLen])
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading log message length")
+		}
+
+		msgLen := int(binary.BigEndian.Uint32(buf[:encodeBinaryLen]))
+		if msgLen > maxMsgLen {
+			return nil, errors.Errorf("log message is too large (%d > %d)", msgLen, maxMsgLen)
+		}
+
+		if len(buf) < msgLen+encodeBinaryLen {
+			buf = make([]byte, msgLen+encodeBinaryLen)
+		}
+
+		// read the message and the trailing size footer
+		if err := readFull(rdr, buf[:msgLen+encodeBinaryLen]); err != nil {
+			return nil, errors.Wrap(err, "could not decode log entry")
+		}
+
+		if footer := int(binary.BigEndian.Uint32(buf[msgLen : msgLen+encodeBinaryLen])); footer != msgLen {
+			return nil, errors.Errorf("log file is corrupted: log entry size mismatch (%d != %d)", msgLen, footer)
+		}
+
+		if err := proto.Unmarshal(buf[:msgLen]); err != nil {
+			return nil, errors.Wrap(err, "error unmarshalling log entry")
+		}
+
+		return protoToMessage(proto), nil
+	}
+}
+
+func protoToMessage(proto *logdriver.LogEntry) *logger.Message {
+	msg := &logger.Message{
+		Source:    proto.Source,
+		Timestamp: time.Unix(0, proto.TimeNano),
+	}
+	msg.Line = append(msg.Line[:0], proto.Line...)
+	if !proto.Partial {
+		msg.Line = append(msg.Line, '\n')
+	}
+	return msg
+}
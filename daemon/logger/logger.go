@@ -91,6 +91,9 @@ type ReadConfig struct {
 	Until  time.Time
 	Tail   int
 	Follow bool
+	// Filter, if set, restricts the returned messages to the ones it
+	// matches. Tail is applied after the filter, to the matching messages.
+	Filter *MessageFilter `json:"-"`
 }
 
 // LogReader is the interface for reading log messages for loggers that support reading.
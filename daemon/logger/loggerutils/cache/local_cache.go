@@ -0,0 +1,162 @@
+// Package cache provides a logger wrapper which keeps a local copy of all
+// messages written to a logging driver that cannot read logs back, so that
+// `docker logs` keeps working for such drivers.
+package cache // import "github.com/docker/docker/daemon/logger/loggerutils/cache"
+
+import (
+	"strconv"
+
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/local"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DriverName is the name of the driver used for local log caching
+	DriverName = local.Name
+
+	cachePrefix      = "cache-"
+	cacheDisabledKey = cachePrefix + "disabled"
+)
+
+var builtInCacheLogOpts = map[string]bool{
+	cacheDisabledKey: true,
+}
+
+func init() {
+	for k, v := range local.LogOptKeys {
+		builtInCacheLogOpts[cachePrefix+k] = v
+	}
+	logger.AddBuiltinLogOpts(builtInCacheLogOpts)
+	logger.RegisterExternalValidator(validateLogCacheOpts)
+}
+
+// WithLocalCache wraps the passed in logger with a logger that caches all
+// writes locally in addition to writing to the passed in logger.
+// The cached logs can be read back through the returned logger, which
+// implements logger.LogReader.
+func WithLocalCache(l logger.Logger, info logger.Info) (logger.Logger, error) {
+	initLogger, err := logger.GetLogDriver(DriverName)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheInfo := info
+	cacheInfo.Config = cacheOpts(info.Config)
+	cacher, err := initLogger(cacheInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "error initializing local log cache driver")
+	}
+
+	lc := &loggerWithCache{
+		l: l,
+		// writes to the cache go through a ring buffer so that a slow disk
+		// never holds up the configured log driver.
+		cache: logger.NewRingLogger(cacher, cacheInfo, -1),
+	}
+	if sl, ok := l.(logger.SizedLogger); ok {
+		return &sizedLoggerWithCache{loggerWithCache: lc, bufSize: sl.BufSize()}, nil
+	}
+	return lc, nil
+}
+
+type loggerWithCache struct {
+	l     logger.Logger
+	cache logger.Logger
+}
+
+func (l *loggerWithCache) Log(msg *logger.Message) error {
+	// copy the message since the underlying logger will return the passed in
+	// message to the message pool once it is done with it.
+	dup := logger.NewMessage()
+	dumbCopyMessage(dup, msg)
+
+	if err := l.l.Log(msg); err != nil {
+		logger.PutMessage(dup)
+		return err
+	}
+	return l.cache.Log(dup)
+}
+
+func (l *loggerWithCache) Name() string {
+	return l.l.Name()
+}
+
+func (l *loggerWithCache) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	return l.cache.(logger.LogReader).ReadLogs(config)
+}
+
+func (l *loggerWithCache) Close() error {
+	err := l.l.Close()
+	if err := l.cache.Close(); err != nil {
+		logrus.WithError(err).Warn("error while shutting cache logger")
+	}
+	return err
+}
+
+// sizedLoggerWithCache preserves the buffer size requested by the wrapped
+// logger so the log copier keeps splitting messages at the same boundaries.
+type sizedLoggerWithCache struct {
+	*loggerWithCache
+	bufSize int
+}
+
+func (l *sizedLoggerWithCache) BufSize() int {
+	return l.bufSize
+}
+
+// ShouldUseCache reports whether the local cache should be used for a
+// container with the passed in log options.
+func ShouldUseCache(cfg map[string]string) bool {
+	v, ok := cfg[cacheDisabledKey]
+	if !ok {
+		return true
+	}
+	disabled, _ := strconv.ParseBool(v)
+	return !disabled
+}
+
+// MergeDefaultLogConfig reads the default log opts and makes sure that any
+// caching related keys that exist there are added to dst.
+func MergeDefaultLogConfig(dst, defaults map[string]string) {
+	for k, v := range defaults {
+		if !builtInCacheLogOpts[k] {
+			continue
+		}
+		if _, exists := dst[k]; !exists {
+			dst[k] = v
+		}
+	}
+}
+
+func validateLogCacheOpts(cfg map[string]string) error {
+	if v, ok := cfg[cacheDisabledKey]; ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			return errors.Errorf("invalid value for option %s: %s", cacheDisabledKey, v)
+		}
+	}
+	return local.ValidateLogOpt(cacheOpts(cfg))
+}
+
+// cacheOpts returns the cache related options from cfg with the cache prefix
+// stripped so they can be passed to the local driver.
+func cacheOpts(cfg map[string]string) map[string]string {
+	opts := make(map[string]string)
+	for k, v := range cfg {
+		if k == cacheDisabledKey || !builtInCacheLogOpts[k] {
+			continue
+		}
+		opts[k[len(cachePrefix):]] = v
+	}
+	return opts
+}
+
+func dumbCopyMessage(dst, src *logger.Message) {
+	dst.Source = src.Source
+	dst.Timestamp = src.Timestamp
+	dst.PLogMetaData = src.PLogMetaData
+	dst.Err = src.Err
+	dst.Attrs = src.Attrs
+	dst.Line = append(dst.Line[:0], src.Line...)
+}
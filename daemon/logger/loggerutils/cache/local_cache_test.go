@@ -0,0 +1,86 @@
+package cache // import "github.com/docker/docker/daemon/logger/loggerutils/cache"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type fakeLogger struct {
+	lines []string
+}
+
+func (l *fakeLogger) Log(msg *logger.Message) error {
+	l.lines = append(l.lines, string(msg.Line))
+	logger.PutMessage(msg)
+	return nil
+}
+
+func (l *fakeLogger) Name() string { return "fake" }
+
+func (l *fakeLogger) Close() error { return nil }
+
+func TestWithLocalCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	fake := &fakeLogger{}
+	l, err := WithLocalCache(fake, logger.Info{LogPath: filepath.Join(dir, "container.log")})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(l.Name(), "fake"))
+
+	for _, line := range []string{"message 1", "message 2"} {
+		msg := logger.NewMessage()
+		msg.Source = "stdout"
+		msg.Timestamp = time.Now()
+		msg.Line = append(msg.Line, line...)
+		assert.NilError(t, l.Log(msg))
+	}
+	assert.Check(t, is.DeepEqual(fake.lines, []string{"message 1", "message 2"}))
+
+	// closing flushes the ring buffer in front of the cache
+	assert.NilError(t, l.Close())
+
+	l, err = WithLocalCache(&fakeLogger{}, logger.Info{LogPath: filepath.Join(dir, "container.log")})
+	assert.NilError(t, err)
+	defer l.Close()
+
+	lw := l.(logger.LogReader).ReadLogs(logger.ReadConfig{Tail: -1})
+	defer lw.Close()
+
+	for _, expected := range []string{"message 1\n", "message 2\n"} {
+		select {
+		case msg := <-lw.Msg:
+			assert.Check(t, is.Equal(string(msg.Line), expected))
+		case err := <-lw.Err:
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for cached log message")
+		}
+	}
+}
+
+func TestShouldUseCache(t *testing.T) {
+	assert.Check(t, ShouldUseCache(map[string]string{}))
+	assert.Check(t, ShouldUseCache(map[string]string{cacheDisabledKey: "false"}))
+	assert.Check(t, !ShouldUseCache(map[string]string{cacheDisabledKey: "true"}))
+}
+
+func TestValidateLogCacheOpts(t *testing.T) {
+	assert.Check(t, validateLogCacheOpts(map[string]string{"cache-max-size": "10m", "cache-max-file": "2", "tag": "foo"}))
+	assert.Check(t, is.ErrorContains(validateLogCacheOpts(map[string]string{cacheDisabledKey: "maybe"}), "invalid value"))
+	assert.Check(t, is.ErrorContains(validateLogCacheOpts(map[string]string{"cache-max-size": "huge"}), "max-size"))
+}
+
+func TestMergeDefaultLogConfig(t *testing.T) {
+	dst := map[string]string{"cache-max-file": "2"}
+	MergeDefaultLogConfig(dst, map[string]string{"cache-max-file": "3", "cache-disabled": "true", "max-file": "5"})
+	assert.Check(t, is.DeepEqual(dst, map[string]string{"cache-max-file": "2", "cache-disabled": "true"}))
+}
@@ -1,7 +1,6 @@
 package loggerutils // import "github.com/docker/docker/daemon/logger/loggerutils"
 
 import (
-	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/json"
@@ -18,7 +17,6 @@ import (
 	"github.com/docker/docker/pkg/filenotify"
 	"github.com/docker/docker/pkg/pools"
 	"github.com/docker/docker/pkg/pubsub"
-	"github.com/docker/docker/pkg/tailfile"
 	"github.com/fsnotify/fsnotify"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -92,13 +90,19 @@ type LogFile struct {
 	notifyRotate    *pubsub.Publisher
 	marshal         logger.MarshalFunc
 	createDecoder   makeDecoderFunc
+	getTailReader   GetTailReaderFunc
 	perms           os.FileMode
 }
 
 type makeDecoderFunc func(rdr io.Reader) func() (*logger.Message, error)
 
+// GetTailReaderFunc is used to truncate a reader to only read as much as is required
+// in order to get the passed in number of log lines.
+// It returns a reader positioned at the start of the oldest requested entry.
+type GetTailReaderFunc func(r io.ReadSeeker, nLogLines int) (io.Reader, error)
+
 // NewLogFile creates new LogFile
-func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, marshaller logger.MarshalFunc, decodeFunc makeDecoderFunc, perms os.FileMode) (*LogFile, error) {
+func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, marshaller logger.MarshalFunc, decodeFunc makeDecoderFunc, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perms)
 	if err != nil {
 		return nil, err
@@ -119,6 +123,7 @@ func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, mar
 		notifyRotate:    pubsub.NewPublisher(0, 1),
 		marshal:         marshaller,
 		createDecoder:   decodeFunc,
+		getTailReader:   getTailReader,
 		perms:           perms,
 	}, nil
 }
@@ -324,7 +329,7 @@ func (w *LogFile) ReadLogs(config logger.ReadConfig, watcher *logger.LogWatcher)
 			seekers = append(seekers, currentChunk)
 		}
 		if len(seekers) > 0 {
-			tailFile(multireader.MultiReadSeeker(seekers...), watcher, w.createDecoder, config)
+			tailFile(multireader.MultiReadSeeker(seekers...), watcher, w.createDecoder, w.getTailReader, config)
 		}
 		for _, f := range files {
 			f.Close()
@@ -348,7 +353,7 @@ func (w *LogFile) ReadLogs(config logger.ReadConfig, watcher *logger.LogWatcher)
 
 	notifyRotate := w.notifyRotate.Subscribe()
 	defer w.notifyRotate.Evict(notifyRotate)
-	followLogs(currentFile, watcher, notifyRotate, w.createDecoder, config.Since, config.Until)
+	followLogs(currentFile, watcher, notifyRotate, w.createDecoder, config.Since, config.Until, config.Filter)
 }
 
 func (w *LogFile) openRotatedFiles(config logger.ReadConfig) (files []*os.File, err error) {
@@ -456,15 +461,32 @@ func newSectionReader(f *os.File) (*io.SectionReader, error) {
 
 type decodeFunc func() (*logger.Message, error)
 
-func tailFile(f io.ReadSeeker, watcher *logger.LogWatcher, createDecoder makeDecoderFunc, config logger.ReadConfig) {
+func tailFile(f io.ReadSeeker, watcher *logger.LogWatcher, createDecoder makeDecoderFunc, getTailReader GetTailReaderFunc, config logger.ReadConfig) {
+	// When filtering, the tail counts matching messages rather than lines.
+	if config.Tail > 0 && config.Filter != nil {
+		matches, err := tailMatches(f, createDecoder, getTailReader, config)
+		if err != nil {
+			watcher.Err <- err
+			return
+		}
+		for _, msg := range matches {
+			select {
+			case <-watcher.WatchClose():
+				return
+			case watcher.Msg <- msg:
+			}
+		}
+		return
+	}
+
 	var rdr io.Reader = f
 	if config.Tail > 0 {
-		ls, err := tailfile.TailFile(f, config.Tail)
+		var err error
+		rdr, err = getTailReader(f, config.Tail)
 		if err != nil {
 			watcher.Err <- err
 			return
 		}
-		rdr = bytes.NewBuffer(bytes.Join(ls, []byte("\n")))
 	}
 
 	decodeLogLine := createDecoder(rdr)
@@ -482,6 +504,9 @@ func tailFile(f io.ReadSeeker, watcher *logger.LogWatcher, createDecoder makeDec
 		if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
 			return
 		}
+		if !config.Filter.Match(msg) {
+			continue
+		}
 		select {
 		case <-watcher.WatchClose():
 			return
@@ -490,7 +515,54 @@ func tailFile(f io.ReadSeeker, watcher *logger.LogWatcher, createDecoder makeDec
 	}
 }
 
-func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, createDecoder makeDecoderFunc, since, until time.Time) {
+// tailMatches returns the last config.Tail messages matching the filter of
+// config. The file is read backwards, in windows of growing sizes, until
+// enough messages match, or the start of the file or the since time is
+// reached.
+func tailMatches(f io.ReadSeeker, createDecoder makeDecoderFunc, getTailReader GetTailReaderFunc, config logger.ReadConfig) ([]*logger.Message, error) {
+	for n := config.Tail; ; n *= 2 {
+		rdr, err := getTailReader(f, n)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			matches     []*logger.Message
+			read        int
+			beforeSince bool
+		)
+		decodeLogLine := createDecoder(rdr)
+		for {
+			msg, err := decodeLogLine()
+			if err != nil {
+				if errors.Cause(err) != io.EOF {
+					return nil, err
+				}
+				break
+			}
+			read++
+			if !config.Since.IsZero() && msg.Timestamp.Before(config.Since) {
+				beforeSince = true
+				continue
+			}
+			if !config.Until.IsZero() && msg.Timestamp.After(config.Until) {
+				continue
+			}
+			if config.Filter.Match(msg) {
+				matches = append(matches, msg)
+			}
+		}
+
+		if len(matches) >= config.Tail || read < n || beforeSince {
+			if len(matches) > config.Tail {
+				matches = matches[len(matches)-config.Tail:]
+			}
+			return matches, nil
+		}
+	}
+}
+
+func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan interface{}, createDecoder makeDecoderFunc, since, until time.Time, filter *logger.MessageFilter) {
 	decodeLogLine := createDecoder(f)
 
 	name := f.Name()
@@ -619,6 +691,9 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 		if !until.IsZero() && msg.Timestamp.After(until) {
 			return
 		}
+		if !filter.Match(msg) {
+			continue
+		}
 		select {
 		case logWatcher.Msg <- msg:
 		case <-ctx.Done():
@@ -634,6 +709,9 @@ func followLogs(f *os.File, logWatcher *logger.LogWatcher, notifyRotate chan int
 				if !until.IsZero() && msg.Timestamp.After(until) {
 					return
 				}
+				if !filter.Match(msg) {
+					continue
+				}
 				logWatcher.Msg <- msg
 			}
 		}
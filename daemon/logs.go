@@ -2,6 +2,7 @@ package daemon // import "github.com/docker/docker/daemon"
 
 import (
 	"context"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/logger"
+	logcache "github.com/docker/docker/daemon/logger/loggerutils/cache"
 	"github.com/docker/docker/errdefs"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -88,11 +90,17 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		until = time.Unix(s, n)
 	}
 
+	filter, err := newLogMessageFilter(config)
+	if err != nil {
+		return nil, false, err
+	}
+
 	readConfig := logger.ReadConfig{
 		Since:  since,
 		Until:  until,
 		Tail:   tailLines,
 		Follow: follow,
+		Filter: filter,
 	}
 
 	logs := logReader.ReadLogs(readConfig)
@@ -159,6 +167,43 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 	return messageChan, container.Config.Tty, nil
 }
 
+// acceptedLogsFilterTags are the filters accepted when reading container logs.
+var acceptedLogsFilterTags = map[string]bool{
+	"attr": true,
+}
+
+// newLogMessageFilter converts the stream selection, grep pattern and filters
+// of the logs options to a filter that is applied by the log reader.
+func newLogMessageFilter(config *types.ContainerLogsOptions) (*logger.MessageFilter, error) {
+	if err := config.Filters.Validate(acceptedLogsFilterTags); err != nil {
+		return nil, err
+	}
+
+	filter := &logger.MessageFilter{
+		Attrs: config.Filters.Get("attr"),
+	}
+	if !config.ShowStdout || !config.ShowStderr {
+		if config.ShowStdout {
+			filter.Sources = append(filter.Sources, "stdout")
+		}
+		if config.ShowStderr {
+			filter.Sources = append(filter.Sources, "stderr")
+		}
+	}
+	if config.Grep != "" {
+		pattern, err := regexp.Compile(config.Grep)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(errors.Wrap(err, "invalid grep pattern"))
+		}
+		filter.Pattern = pattern
+	}
+
+	if len(filter.Sources) == 0 && filter.Pattern == nil && len(filter.Attrs) == 0 {
+		return nil, nil
+	}
+	return filter, nil
+}
+
 func (daemon *Daemon) getLogger(container *container.Container) (l logger.Logger, created bool, err error) {
 	container.Lock()
 	if container.State.Running {
@@ -190,6 +235,8 @@ func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) err
 		}
 	}
 
+	logcache.MergeDefaultLogConfig(cfg.Config, daemon.defaultLogConfig.Config)
+
 	return logger.ValidateLogOpts(cfg.Type, cfg.Config)
 }
 
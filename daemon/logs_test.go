@@ -3,7 +3,11 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 )
 
 func TestMergeAndVerifyLogConfigNilConfig(t *testing.T) {
@@ -13,3 +17,25 @@ func TestMergeAndVerifyLogConfigNilConfig(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestNewLogMessageFilter(t *testing.T) {
+	f, err := newLogMessageFilter(&types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(f))
+
+	f, err = newLogMessageFilter(&types.ContainerLogsOptions{
+		ShowStderr: true,
+		Grep:       "^err",
+		Filters:    filters.NewArgs(filters.Arg("attr", "tag=web")),
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(f.Sources, []string{"stderr"}))
+	assert.Check(t, is.DeepEqual(f.Attrs, []string{"tag=web"}))
+	assert.Check(t, f.Pattern.MatchString("error"))
+
+	_, err = newLogMessageFilter(&types.ContainerLogsOptions{ShowStdout: true, Grep: "("})
+	assert.Check(t, is.ErrorContains(err, "invalid grep pattern"))
+
+	_, err = newLogMessageFilter(&types.ContainerLogsOptions{ShowStdout: true, Filters: filters.NewArgs(filters.Arg("foo", "bar"))})
+	assert.Check(t, is.ErrorContains(err, "Invalid filter"))
+}
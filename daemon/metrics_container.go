@@ -0,0 +1,200 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/stats"
+	"github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// containerMetrics exports the resource usage of the running containers as
+// Prometheus metrics. The usage is sampled by the stats collector, the same
+// way as for the stats API, and the latest sample of each container is kept
+// until the metrics are collected.
+type containerMetrics struct {
+	mu         sync.Mutex
+	collector  *stats.Collector
+	containers map[string]*containerMetricsEntry
+
+	// labelKeys are the keys of the container labels exported, and
+	// metricsLabels the names of all the labels of the metrics.
+	labelKeys     []string
+	metricsLabels []string
+
+	ns          *metrics.Namespace
+	cpuUsage    *prometheus.Desc
+	memoryUsage *prometheus.Desc
+	memoryLimit *prometheus.Desc
+	blkioRead   *prometheus.Desc
+	blkioWrite  *prometheus.Desc
+	networkRx   *prometheus.Desc
+	networkTx   *prometheus.Desc
+	pidsCurrent *prometheus.Desc
+}
+
+type containerMetricsEntry struct {
+	ch          chan interface{}
+	labelValues []string
+	stats       *types.StatsJSON
+}
+
+// newContainerMetrics creates the per-container metrics. The values of the
+// container labels listed in labelKeys are added as labels to the metrics.
+func newContainerMetrics(collector *stats.Collector, labelKeys []string) *containerMetrics {
+	m := &containerMetrics{
+		collector:  collector,
+		containers: make(map[string]*containerMetricsEntry),
+		ns:         metrics.NewNamespace("engine", "daemon", nil),
+	}
+
+	m.metricsLabels = []string{"name", "image"}
+	seen := map[string]bool{"name": true, "image": true}
+	for _, k := range labelKeys {
+		l := metricLabelName(k)
+		if seen[l] {
+			continue
+		}
+		seen[l] = true
+		m.labelKeys = append(m.labelKeys, k)
+		m.metricsLabels = append(m.metricsLabels, l)
+	}
+
+	m.cpuUsage = m.ns.NewDesc("container_cpu_usage_seconds", "The total CPU time consumed by the container", metrics.Total, m.metricsLabels...)
+	m.memoryUsage = m.ns.NewDesc("container_memory_usage", "The memory usage of the container", metrics.Bytes, m.metricsLabels...)
+	m.memoryLimit = m.ns.NewDesc("container_memory_limit", "The memory limit of the container", metrics.Bytes, m.metricsLabels...)
+	m.blkioRead = m.ns.NewDesc("container_blkio_read_bytes", "The number of bytes read from block devices by the container", metrics.Total, m.metricsLabels...)
+	m.blkioWrite = m.ns.NewDesc("container_blkio_write_bytes", "The number of bytes written to block devices by the container", metrics.Total, m.metricsLabels...)
+	m.networkRx = m.ns.NewDesc("container_network_receive_bytes", "The number of bytes received by the container on all its interfaces", metrics.Total, m.metricsLabels...)
+	m.networkTx = m.ns.NewDesc("container_network_transmit_bytes", "The number of bytes transmitted by the container on all its interfaces", metrics.Total, m.metricsLabels...)
+	m.pidsCurrent = m.ns.NewDesc("container_pids_current", "The number of processes running in the container", "", m.metricsLabels...)
+	m.ns.Add(m)
+
+	return m
+}
+
+// metricLabelName converts a container label key, such as
+// "com.example.team", to a valid Prometheus label name, such as
+// "label_com_example_team".
+func metricLabelName(key string) string {
+	return "label_" + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// update starts sampling the resource usage of the container when it is
+// running or paused. The sampling stops by itself once the container is
+// stopped or removed.
+func (m *containerMetrics) update(c *container.Container, state string) {
+	if state != "running" && state != "paused" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.containers[c.ID]; ok {
+		return
+	}
+
+	labelValues := []string{strings.TrimPrefix(c.Name, "/"), c.Config.Image}
+	for _, k := range m.labelKeys {
+		labelValues = append(labelValues, c.Config.Labels[k])
+	}
+	e := &containerMetricsEntry{
+		ch:          m.collector.Collect(c),
+		labelValues: labelValues,
+	}
+	m.containers[c.ID] = e
+	go m.run(c, e)
+}
+
+func (m *containerMetrics) run(c *container.Container, e *containerMetricsEntry) {
+	defer m.remove(c.ID, e)
+
+	for v := range e.ch {
+		s, ok := v.(types.StatsJSON)
+		if !ok {
+			continue
+		}
+		// Empty stats are published when the container is not running.
+		if s.Read.IsZero() {
+			if !c.IsRunning() {
+				m.collector.Unsubscribe(c, e.ch)
+				return
+			}
+			continue
+		}
+		m.mu.Lock()
+		e.stats = &s
+		m.mu.Unlock()
+	}
+}
+
+func (m *containerMetrics) remove(id string, e *containerMetricsEntry) {
+	m.mu.Lock()
+	if m.containers[id] == e {
+		delete(m.containers, id)
+	}
+	m.mu.Unlock()
+}
+
+func (m *containerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.cpuUsage
+	ch <- m.memoryUsage
+	ch <- m.memoryLimit
+	ch <- m.blkioRead
+	ch <- m.blkioWrite
+	ch <- m.networkRx
+	ch <- m.networkTx
+	ch <- m.pidsCurrent
+}
+
+func (m *containerMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.containers {
+		s := e.stats
+		if s == nil {
+			continue
+		}
+		gauge := func(desc *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, e.labelValues...)
+		}
+		counter := func(desc *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v, e.labelValues...)
+		}
+
+		counter(m.cpuUsage, float64(s.CPUStats.CPUUsage.TotalUsage)/1e9)
+		gauge(m.memoryUsage, float64(s.MemoryStats.Usage))
+		gauge(m.memoryLimit, float64(s.MemoryStats.Limit))
+
+		var read, write uint64
+		for _, entry := range s.BlkioStats.IoServiceBytesRecursive {
+			switch strings.ToLower(entry.Op) {
+			case "read":
+				read += entry.Value
+			case "write":
+				write += entry.Value
+			}
+		}
+		counter(m.blkioRead, float64(read))
+		counter(m.blkioWrite, float64(write))
+
+		var rx, tx uint64
+		for _, n := range s.Networks {
+			rx += n.RxBytes
+			tx += n.TxBytes
+		}
+		counter(m.networkRx, float64(rx))
+		counter(m.networkTx, float64(tx))
+
+		gauge(m.pidsCurrent, float64(s.PidsStats.Current))
+	}
+}
@@ -0,0 +1,84 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestMetricLabelName(t *testing.T) {
+	assert.Check(t, is.Equal("label_com_example_team", metricLabelName("com.example.team")))
+	assert.Check(t, is.Equal("label_env", metricLabelName("env")))
+	assert.Check(t, is.Equal("label_a_b_c", metricLabelName("a-b/c")))
+}
+
+func TestContainerMetricsLabels(t *testing.T) {
+	m := newContainerMetrics(nil, []string{"com.example.team", "com-example-team", "env"})
+	assert.Check(t, is.DeepEqual([]string{"com.example.team", "env"}, m.labelKeys))
+	assert.Check(t, is.DeepEqual([]string{"name", "image", "label_com_example_team", "label_env"}, m.metricsLabels))
+}
+
+func TestContainerMetricsCollect(t *testing.T) {
+	m := newContainerMetrics(nil, []string{"env"})
+
+	s := types.StatsJSON{}
+	s.Read = time.Now()
+	s.CPUStats.CPUUsage.TotalUsage = 1500000000
+	s.MemoryStats.Usage = 1024
+	s.MemoryStats.Limit = 4096
+	s.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 10},
+		{Major: 8, Minor: 0, Op: "Write", Value: 20},
+		{Major: 8, Minor: 16, Op: "read", Value: 5},
+		{Major: 8, Minor: 16, Op: "Total", Value: 35},
+	}
+	s.Networks = map[string]types.NetworkStats{
+		"eth0": {RxBytes: 100, TxBytes: 200},
+		"eth1": {RxBytes: 1, TxBytes: 2},
+	}
+	s.PidsStats.Current = 3
+
+	m.containers["running"] = &containerMetricsEntry{labelValues: []string{"web", "nginx", "prod"}, stats: &s}
+	// Containers without a sample yet are not reported.
+	m.containers["starting"] = &containerMetricsEntry{labelValues: []string{"db", "postgres", ""}}
+
+	ch := make(chan prometheus.Metric, 100)
+	m.Collect(ch)
+	close(ch)
+
+	gauges := make(map[string]float64)
+	counters := make(map[string]float64)
+	for metric := range ch {
+		var pb dto.Metric
+		assert.NilError(t, metric.Write(&pb))
+		labels := make(map[string]string)
+		for _, l := range pb.Label {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Check(t, is.DeepEqual(map[string]string{"name": "web", "image": "nginx", "label_env": "prod"}, labels))
+		if pb.Counter != nil {
+			counters[metric.Desc().String()] = pb.GetCounter().GetValue()
+		} else {
+			gauges[metric.Desc().String()] = pb.GetGauge().GetValue()
+		}
+	}
+
+	assert.Check(t, is.Len(gauges, 3))
+	assert.Check(t, is.Equal(float64(1024), gauges[m.memoryUsage.String()]))
+	assert.Check(t, is.Equal(float64(4096), gauges[m.memoryLimit.String()]))
+	assert.Check(t, is.Equal(float64(3), gauges[m.pidsCurrent.String()]))
+
+	assert.Check(t, is.Len(counters, 5))
+	assert.Check(t, is.Equal(1.5, counters[m.cpuUsage.String()]))
+	assert.Check(t, is.Equal(float64(15), counters[m.blkioRead.String()]))
+	assert.Check(t, is.Equal(float64(20), counters[m.blkioWrite.String()]))
+	assert.Check(t, is.Equal(float64(101), counters[m.networkRx.String()]))
+	assert.Check(t, is.Equal(float64(202), counters[m.networkTx.String()]))
+	assert.Check(t, is.Contains(m.cpuUsage.String(), `"engine_daemon_container_cpu_usage_seconds_total"`))
+	assert.Check(t, is.Contains(m.networkRx.String(), `"engine_daemon_container_network_receive_bytes_total"`))
+}
@@ -16,7 +16,8 @@ import (
 )
 
 func (daemon *Daemon) setStateCounter(c *container.Container) {
-	switch c.StateString() {
+	state := c.StateString()
+	switch state {
 	case "paused":
 		stateCtr.set(c.ID, "paused")
 	case "running":
@@ -24,6 +25,9 @@ func (daemon *Daemon) setStateCounter(c *container.Container) {
 	default:
 		stateCtr.set(c.ID, "stopped")
 	}
+	if daemon.containerMetrics != nil {
+		daemon.containerMetrics.update(c, state)
+	}
 }
 
 // ProcessEvent is called by libcontainerd whenever an event occurs
@@ -40,6 +44,13 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			return errors.New("received StateOOM from libcontainerd on Windows. This should never happen")
 		}
 
+		// Sample the memory usage as close as possible to the kill.
+		attributes := map[string]string{}
+		if s, err := daemon.stats(c); err == nil {
+			attributes["memoryUsage"] = strconv.FormatUint(s.MemoryStats.Usage, 10)
+			attributes["memoryLimit"] = strconv.FormatUint(s.MemoryStats.Limit, 10)
+		}
+
 		c.Lock()
 		defer c.Unlock()
 		daemon.updateHealthMonitor(c)
@@ -47,7 +58,7 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			return err
 		}
 
-		daemon.LogContainerEvent(c, "oom")
+		daemon.LogContainerEventWithAttributes(c, "oom", attributes)
 	case libcontainerd.EventExit:
 		if int(ei.Pid) == c.Pid {
 			c.Lock()
@@ -65,8 +76,12 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 				OOMKilled: ei.OOMKilled,
 			}
 			restart, wait, err := c.RestartManager().ShouldRestart(ei.ExitCode, daemon.IsShuttingDown() || c.HasBeenManuallyStopped, time.Since(c.StartedAt))
+			var enteredCrashLoop bool
 			if err == nil && restart {
 				c.RestartCount++
+				crashLooping := c.RestartManager().CrashLooping()
+				enteredCrashLoop = crashLooping && !c.CrashLooping
+				c.CrashLooping = crashLooping
 				c.SetRestarting(&exitStatus)
 			} else {
 				if ei.Error != nil {
@@ -84,6 +99,11 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 				"exitCode": strconv.Itoa(int(ei.ExitCode)),
 			}
 			daemon.LogContainerEventWithAttributes(c, "die", attributes)
+			if enteredCrashLoop {
+				daemon.LogContainerEventWithAttributes(c, "crash-loop", map[string]string{
+					"restartCount": strconv.Itoa(c.RestartCount),
+				})
+			}
 			daemon.Cleanup(c)
 
 			if err == nil && restart {
@@ -118,7 +138,7 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			ec := int(ei.ExitCode)
 			execConfig.Lock()
 			defer execConfig.Unlock()
-			execConfig.ExitCode = &ec
+			execConfig.SetExitCode(ec)
 			execConfig.Running = false
 			execConfig.StreamConfig.Wait()
 			if err := execConfig.CloseStreams(); err != nil {
@@ -210,3 +230,25 @@ func (daemon *Daemon) autoRemove(c *container.Container) {
 		logrus.WithError(err).WithField("container", c.ID).Error("error removing container")
 	}
 }
+
+// clearCrashLoop clears the crash-looping state of a running container once
+// it has been running for longer than the reset window of its restart
+// policy. It must be called with the container locked.
+func (daemon *Daemon) clearCrashLoop(c *container.Container) {
+	if !c.CrashLooping {
+		return
+	}
+	startedAt := c.StartedAt
+	window := restartmanager.ResetWindow(c.HostConfig.RestartPolicy) - time.Since(startedAt)
+	time.AfterFunc(window, func() {
+		c.Lock()
+		defer c.Unlock()
+		if !c.CrashLooping || !c.Running || !c.StartedAt.Equal(startedAt) {
+			return
+		}
+		c.CrashLooping = false
+		if err := c.CheckpointTo(daemon.containersReplica); err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Error("failed to store container")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"gotest.tools/assert"
+	"gotest.tools/poll"
+)
+
+func TestClearCrashLoop(t *testing.T) {
+	c := &container.Container{
+		ID:     "container_id",
+		Name:   "container_name",
+		Config: &containertypes.Config{Image: "image_name"},
+		HostConfig: &containertypes.HostConfig{
+			RestartPolicy: containertypes.RestartPolicy{
+				Name:    "always",
+				Backoff: &containertypes.RestartBackoff{ResetWindow: 50 * time.Millisecond},
+			},
+		},
+		State: container.NewState(),
+	}
+	store, err := container.NewViewDB()
+	assert.NilError(t, err)
+	daemon := &Daemon{containersReplica: store}
+
+	c.SetRunning(1, true)
+	c.CrashLooping = true
+	c.Lock()
+	daemon.clearCrashLoop(c)
+	c.Unlock()
+
+	c.Lock()
+	assert.Assert(t, c.CrashLooping, "crash loop cleared before the reset window")
+	c.Unlock()
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		c.Lock()
+		defer c.Unlock()
+		if c.CrashLooping {
+			return poll.Continue("container is still crash looping")
+		}
+		return poll.Success()
+	}, poll.WithDelay(10*time.Millisecond), poll.WithTimeout(5*time.Second))
+}
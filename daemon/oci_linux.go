@@ -305,6 +305,12 @@ func setNamespaces(daemon *Daemon, s *specs.Spec, c *container.Container) error
 		s.Hostname = ""
 	}
 
+	// cgroup
+	if c.HostConfig.CgroupnsMode.IsPrivate() {
+		ns := specs.LinuxNamespace{Type: "cgroup"}
+		setNamespace(s, ns)
+	}
+
 	return nil
 }
 
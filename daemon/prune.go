@@ -35,8 +35,9 @@ var (
 	}
 )
 
-// ContainersPrune removes unused containers
-func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.Args) (*types.ContainersPruneReport, error) {
+// ContainersPrune removes unused containers. If dryRun is true, nothing is
+// removed, and the report of what would be removed is returned.
+func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (*types.ContainersPruneReport, error) {
 	if !atomic.CompareAndSwapInt32(&daemon.pruneRunning, 0, 1) {
 		return nil, errPruneRunning
 	}
@@ -72,11 +73,13 @@ func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.
 				continue
 			}
 			cSize, _ := daemon.imageService.GetContainerLayerSize(c.ID)
-			// TODO: sets RmLink to true?
-			err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{})
-			if err != nil {
-				logrus.Warnf("failed to prune container %s: %v", c.ID, err)
-				continue
+			if !dryRun {
+				// TODO: sets RmLink to true?
+				err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{})
+				if err != nil {
+					logrus.Warnf("failed to prune container %s: %v", c.ID, err)
+					continue
+				}
 			}
 			if cSize > 0 {
 				rep.SpaceReclaimed += uint64(cSize)
@@ -89,7 +92,7 @@ func (daemon *Daemon) ContainersPrune(ctx context.Context, pruneFilters filters.
 }
 
 // localNetworksPrune removes unused local networks
-func (daemon *Daemon) localNetworksPrune(ctx context.Context, pruneFilters filters.Args) *types.NetworksPruneReport {
+func (daemon *Daemon) localNetworksPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) *types.NetworksPruneReport {
 	rep := &types.NetworksPruneReport{}
 
 	until, _ := getUntilFromPruneFilters(pruneFilters)
@@ -118,6 +121,10 @@ func (daemon *Daemon) localNetworksPrune(ctx context.Context, pruneFilters filte
 		if len(nw.Endpoints()) > 0 {
 			return false
 		}
+		if dryRun {
+			rep.NetworksDeleted = append(rep.NetworksDeleted, nwName)
+			return false
+		}
 		if err := daemon.DeleteNetwork(nw.ID()); err != nil {
 			logrus.Warnf("could not remove local network %s: %v", nwName, err)
 			return false
@@ -130,7 +137,7 @@ func (daemon *Daemon) localNetworksPrune(ctx context.Context, pruneFilters filte
 }
 
 // clusterNetworksPrune removes unused cluster networks
-func (daemon *Daemon) clusterNetworksPrune(ctx context.Context, pruneFilters filters.Args) (*types.NetworksPruneReport, error) {
+func (daemon *Daemon) clusterNetworksPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (*types.NetworksPruneReport, error) {
 	rep := &types.NetworksPruneReport{}
 
 	until, _ := getUntilFromPruneFilters(pruneFilters)
@@ -145,6 +152,13 @@ func (daemon *Daemon) clusterNetworksPrune(ctx context.Context, pruneFilters fil
 	if err != nil {
 		return rep, err
 	}
+	var inUse map[string]bool
+	if dryRun {
+		inUse, err = daemon.clusterNetworksInUse()
+		if err != nil {
+			return rep, err
+		}
+	}
 	networkIsInUse := regexp.MustCompile(`network ([[:alnum:]]+) is in use`)
 	for _, nw := range networks {
 		select {
@@ -161,6 +175,12 @@ func (daemon *Daemon) clusterNetworksPrune(ctx context.Context, pruneFilters fil
 			if !matchLabels(pruneFilters, nw.Labels) {
 				continue
 			}
+			if dryRun {
+				if !inUse[nw.ID] && !inUse[nw.Name] {
+					rep.NetworksDeleted = append(rep.NetworksDeleted, nw.Name)
+				}
+				continue
+			}
 			// https://github.com/docker/docker/issues/24186
 			// `docker network inspect` unfortunately displays ONLY those containers that are local to that node.
 			// So we try to remove it anyway and check the error
@@ -179,8 +199,34 @@ func (daemon *Daemon) clusterNetworksPrune(ctx context.Context, pruneFilters fil
 	return rep, nil
 }
 
-// NetworksPrune removes unused networks
-func (daemon *Daemon) NetworksPrune(ctx context.Context, pruneFilters filters.Args) (*types.NetworksPruneReport, error) {
+// clusterNetworksInUse returns the IDs and names of the cluster networks
+// used by services, or by containers of this node.
+func (daemon *Daemon) clusterNetworksInUse() (map[string]bool, error) {
+	services, err := daemon.GetCluster().GetServices(types.ServiceListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	inUse := make(map[string]bool)
+	for _, s := range services {
+		for _, n := range s.Spec.TaskTemplate.Networks {
+			inUse[n.Target] = true
+		}
+		for _, n := range s.Spec.Networks {
+			inUse[n.Target] = true
+		}
+	}
+	daemon.netController.WalkNetworks(func(nw libnetwork.Network) bool {
+		if len(nw.Endpoints()) > 0 {
+			inUse[nw.ID()] = true
+		}
+		return false
+	})
+	return inUse, nil
+}
+
+// NetworksPrune removes unused networks. If dryRun is true, nothing is
+// removed, and the report of what would be removed is returned.
+func (daemon *Daemon) NetworksPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (*types.NetworksPruneReport, error) {
 	if !atomic.CompareAndSwapInt32(&daemon.pruneRunning, 0, 1) {
 		return nil, errPruneRunning
 	}
@@ -197,11 +243,11 @@ func (daemon *Daemon) NetworksPrune(ctx context.Context, pruneFilters filters.Ar
 	}
 
 	rep := &types.NetworksPruneReport{}
-	if clusterRep, err := daemon.clusterNetworksPrune(ctx, pruneFilters); err == nil {
+	if clusterRep, err := daemon.clusterNetworksPrune(ctx, pruneFilters, dryRun); err == nil {
 		rep.NetworksDeleted = append(rep.NetworksDeleted, clusterRep.NetworksDeleted...)
 	}
 
-	localRep := daemon.localNetworksPrune(ctx, pruneFilters)
+	localRep := daemon.localNetworksPrune(ctx, pruneFilters, dryRun)
 	rep.NetworksDeleted = append(rep.NetworksDeleted, localRep.NetworksDeleted...)
 
 	select {
@@ -22,6 +22,7 @@ import (
 // - Insecure registries
 // - Registry mirrors
 // - Daemon live restore
+// - Garbage collection thresholds
 func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	daemon.configStore.Lock()
 	attributes := map[string]string{}
@@ -45,6 +46,7 @@ func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	daemon.reloadDebug(conf, attributes)
 	daemon.reloadMaxConcurrentDownloadsAndUploads(conf, attributes)
 	daemon.reloadShutdownTimeout(conf, attributes)
+	daemon.reloadGC(conf, attributes)
 
 	if err := daemon.reloadClusterDiscovery(conf, attributes); err != nil {
 		return err
@@ -121,6 +123,38 @@ func (daemon *Daemon) reloadShutdownTimeout(conf *config.Config, attributes map[
 	attributes["shutdown-timeout"] = fmt.Sprintf("%d", daemon.configStore.ShutdownTimeout)
 }
 
+// reloadGC updates configuration with the garbage collection options
+// and updates the passed attributes
+func (daemon *Daemon) reloadGC(conf *config.Config, attributes map[string]string) {
+	gc := &daemon.configStore.GCConfig
+	if conf.IsValueSet("gc-interval") {
+		gc.Interval = conf.Interval
+	}
+	if conf.IsValueSet("gc-max-images-size") {
+		gc.MaxImagesSize = conf.MaxImagesSize
+	}
+	if conf.IsValueSet("gc-build-cache-keep-storage") {
+		gc.BuildCacheKeepStorage = conf.BuildCacheKeepStorage
+	}
+	if conf.IsValueSet("gc-min-free-space") {
+		gc.MinFreeSpace = conf.MinFreeSpace
+	}
+	if conf.IsValueSet("gc-max-age") {
+		gc.MaxAge = conf.MaxAge
+	}
+	if conf.IsValueSet("gc-min-age") {
+		gc.MinAge = conf.MinAge
+	}
+
+	// prepare reload event attributes with updatable configurations
+	attributes["gc-interval"] = gc.Interval.String()
+	attributes["gc-max-images-size"] = fmt.Sprintf("%d", gc.MaxImagesSize)
+	attributes["gc-build-cache-keep-storage"] = fmt.Sprintf("%d", gc.BuildCacheKeepStorage)
+	attributes["gc-min-free-space"] = fmt.Sprintf("%d", gc.MinFreeSpace)
+	attributes["gc-max-age"] = gc.MaxAge.String()
+	attributes["gc-min-age"] = gc.MinAge.String()
+}
+
 // reloadClusterDiscovery updates configuration with cluster discovery options
 // and updates the passed attributes
 func (daemon *Daemon) reloadClusterDiscovery(conf *config.Config, attributes map[string]string) (err error) {
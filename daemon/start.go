@@ -199,6 +199,7 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 	daemon.setStateCounter(container)
 
 	daemon.initHealthMonitor(container)
+	daemon.clearCrashLoop(container)
 
 	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
 		logrus.WithError(err).WithField("container", container.ID).
@@ -238,6 +239,9 @@ func (daemon *Daemon) Cleanup(container *container.Container) {
 
 	for _, eConfig := range container.ExecCommands.Commands() {
 		daemon.unregisterExecCommand(container, eConfig)
+		eConfig.Lock()
+		eConfig.SetExited()
+		eConfig.Unlock()
 	}
 
 	if container.BaseFS != nil && container.BaseFS.Path() != "" {
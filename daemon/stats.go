@@ -129,6 +129,166 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 	}
 }
 
+// containerStatsSubscription is the subscription of a ContainersStats call
+// to the stats of one of the containers.
+type containerStatsSubscription struct {
+	container   *container.Container
+	updates     chan interface{}
+	preCPUStats types.CPUStats
+	preRead     time.Time
+	frames      int
+}
+
+type containerStatsUpdate struct {
+	sub   *containerStatsSubscription
+	stats types.StatsJSON
+	done  bool
+}
+
+// ContainersStats writes the stats of all the running containers matching the
+// filters given in the config object to its stream. When streaming, the set
+// of containers is re-evaluated on every stats interval, so that containers
+// are added and dropped as they start and stop.
+func (daemon *Daemon) ContainersStats(ctx context.Context, config *backend.ContainersStatsConfig) error {
+	listConfig := &types.ContainerListOptions{Filters: config.Filters}
+	// Validate the filters before the stream starts.
+	list, err := daemon.Containers(listConfig)
+	if err != nil {
+		return err
+	}
+
+	outStream := config.OutStream
+	if config.Stream {
+		wf := ioutils.NewWriteFlusher(outStream)
+		defer wf.Close()
+		wf.Flush()
+		outStream = wf
+	}
+	enc := json.NewEncoder(outStream)
+
+	var (
+		subs    = make(map[string]*containerStatsSubscription)
+		updates = make(chan containerStatsUpdate)
+		stop    = make(chan struct{})
+	)
+	defer func() {
+		close(stop)
+		for _, sub := range subs {
+			daemon.unsubscribeToContainerStats(sub.container, sub.updates)
+		}
+	}()
+
+	subscribe := func(list []*types.Container) {
+		seen := make(map[string]bool)
+		for _, c := range list {
+			seen[c.ID] = true
+			if _, ok := subs[c.ID]; ok {
+				continue
+			}
+			ctr, err := daemon.GetContainer(c.ID)
+			if err != nil {
+				continue
+			}
+			sub := &containerStatsSubscription{
+				container: ctr,
+				updates:   daemon.subscribeToContainerStats(ctr),
+			}
+			subs[c.ID] = sub
+			go forwardContainerStats(sub, updates, stop)
+		}
+		for id, sub := range subs {
+			if !seen[id] {
+				daemon.unsubscribeToContainerStats(sub.container, sub.updates)
+				delete(subs, id)
+			}
+		}
+	}
+	subscribe(list)
+
+	// Without streaming, the stats of each container are written once,
+	// after a first frame to prime the cpu stats.
+	pending := len(subs)
+	if !config.Stream && pending == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(daemon.statsCollector.Interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case u := <-updates:
+			if subs[u.sub.container.ID] != u.sub {
+				// The container has been dropped.
+				continue
+			}
+			if u.done || u.stats.Read.IsZero() {
+				// The container has been removed or is not running.
+				if u.done {
+					delete(subs, u.sub.container.ID)
+				}
+				if !config.Stream && u.sub.frames < 2 {
+					u.sub.frames = 2
+					if pending--; pending == 0 {
+						return nil
+					}
+				}
+				continue
+			}
+
+			sub := u.sub
+			ss := u.stats
+			ss.Name = sub.container.Name
+			ss.ID = sub.container.ID
+			ss.PreCPUStats = sub.preCPUStats
+			ss.PreRead = sub.preRead
+			sub.preCPUStats = ss.CPUStats
+			sub.preRead = ss.Read
+			sub.frames++
+
+			if !config.Stream && sub.frames != 2 {
+				continue
+			}
+			if err := enc.Encode(&ss); err != nil {
+				return err
+			}
+			if !config.Stream {
+				if pending--; pending == 0 {
+					return nil
+				}
+			}
+		case <-ticker.C:
+			if !config.Stream {
+				continue
+			}
+			list, err := daemon.Containers(listConfig)
+			if err != nil {
+				return err
+			}
+			subscribe(list)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// forwardContainerStats forwards the stats published for the container of a
+// subscription to the updates channel, until the subscription is closed or
+// stop is closed.
+func forwardContainerStats(sub *containerStatsSubscription, updates chan<- containerStatsUpdate, stop <-chan struct{}) {
+	for v := range sub.updates {
+		select {
+		case updates <- containerStatsUpdate{sub: sub, stats: v.(types.StatsJSON)}:
+		case <-stop:
+			return
+		}
+	}
+	select {
+	case updates <- containerStatsUpdate{sub: sub, done: true}:
+	case <-stop:
+	}
+}
+
 func (daemon *Daemon) subscribeToContainerStats(c *container.Container) chan interface{} {
 	return daemon.statsCollector.Collect(c)
 }
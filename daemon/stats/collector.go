@@ -37,6 +37,11 @@ func NewCollector(supervisor supervisor, interval time.Duration) *Collector {
 	return s
 }
 
+// Interval returns the interval at which the stats are collected.
+func (s *Collector) Interval() time.Duration {
+	return s.interval
+}
+
 type supervisor interface {
 	// GetContainerStats collects all the stats related to a container
 	GetContainerStats(container *container.Container) (*types.StatsJSON, error)
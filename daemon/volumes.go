@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -382,6 +383,38 @@ func (daemon *Daemon) VolumesService() *service.VolumesService {
 	return daemon.volumes
 }
 
+// VolumeReferences returns the mounts of the volume by the containers among
+// the references to the volume. The references which are not containers are
+// skipped.
+func (daemon *Daemon) VolumeReferences(volumeName string, refs []string) []types.VolumeReference {
+	view := daemon.containersReplica.Snapshot()
+
+	var out []types.VolumeReference
+	for _, ref := range refs {
+		c, err := view.Get(ref)
+		if err != nil {
+			continue
+		}
+		for _, m := range c.Mounts {
+			if m.Type != mounttypes.TypeVolume || m.Name != volumeName {
+				continue
+			}
+			out = append(out, types.VolumeReference{
+				ContainerID:   c.ID,
+				ContainerName: strings.TrimPrefix(c.Name, "/"),
+				Destination:   m.Destination,
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ContainerName != out[j].ContainerName {
+			return out[i].ContainerName < out[j].ContainerName
+		}
+		return out[i].Destination < out[j].Destination
+	})
+	return out
+}
+
 type volumeMounter interface {
 	Mount(ctx context.Context, v *types.Volume, ref string) (string, error)
 	Unmount(ctx context.Context, v *types.Volume, ref string) error
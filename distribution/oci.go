@@ -1,9 +1,11 @@
 package distribution
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest"
 	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema2"
 	digest "github.com/opencontainers/go-digest"
@@ -43,3 +45,76 @@ func init() {
 		panic(fmt.Sprintf("Unable to register manifest: %s", err))
 	}
 }
+
+// ociManifestBuilder builds OCI image manifests. They are pushed instead of
+// schema2 manifests when the layers are zstd-compressed, which schema2 cannot
+// describe. As for pulls, the schema2 types are used since both formats share
+// the same structure.
+type ociManifestBuilder struct {
+	bs           distribution.BlobService
+	configJSON   []byte
+	dependencies []distribution.Descriptor
+}
+
+func newOCIManifestBuilder(bs distribution.BlobService, configJSON []byte) distribution.ManifestBuilder {
+	mb := &ociManifestBuilder{
+		bs:         bs,
+		configJSON: make([]byte, len(configJSON)),
+	}
+	copy(mb.configJSON, configJSON)
+	return mb
+}
+
+// Build produces an OCI manifest from the given references, pushing the
+// configuration blob if needed.
+func (mb *ociManifestBuilder) Build(ctx context.Context) (distribution.Manifest, error) {
+	m := schema2.Manifest{
+		Versioned: manifest.Versioned{
+			SchemaVersion: 2,
+			MediaType:     ocispec.MediaTypeImageManifest,
+		},
+		Layers: make([]distribution.Descriptor, len(mb.dependencies)),
+	}
+	for i, d := range mb.dependencies {
+		d.MediaType = ociLayerMediaType(d.MediaType)
+		m.Layers[i] = d
+	}
+
+	var err error
+	m.Config, err = mb.bs.Stat(ctx, digest.FromBytes(mb.configJSON))
+	switch err {
+	case nil:
+	case distribution.ErrBlobUnknown:
+		if m.Config, err = mb.bs.Put(ctx, ocispec.MediaTypeImageConfig, mb.configJSON); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	// Stat and Put do not return the media type of the blob.
+	m.Config.MediaType = ocispec.MediaTypeImageConfig
+
+	return schema2.FromStruct(m)
+}
+
+// AppendReference adds a reference to the current ManifestBuilder.
+func (mb *ociManifestBuilder) AppendReference(d distribution.Describable) error {
+	mb.dependencies = append(mb.dependencies, d.Descriptor())
+	return nil
+}
+
+// References returns the current references added to this builder.
+func (mb *ociManifestBuilder) References() []distribution.Descriptor {
+	return mb.dependencies
+}
+
+// ociLayerMediaType returns the OCI equivalent of the media type of a layer.
+func ociLayerMediaType(mediaType string) string {
+	switch mediaType {
+	case schema2.MediaTypeLayer:
+		return ocispec.MediaTypeImageLayerGzip
+	case schema2.MediaTypeForeignLayer:
+		return ocispec.MediaTypeImageLayerNonDistributableGzip
+	}
+	return mediaType
+}
@@ -19,7 +19,9 @@ import (
 	apitypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/stringid"
@@ -143,7 +145,13 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 
 	var descriptors []xfer.UploadDescriptor
 
+	compression := p.config.LayerCompression
+	if compression == archive.Uncompressed {
+		compression = archive.Gzip
+	}
+
 	descriptorTemplate := v2PushDescriptor{
+		compression:       compression,
 		v2MetadataService: p.v2MetadataService,
 		hmacKey:           hmacKey,
 		repoInfo:          p.repoInfo.Name,
@@ -167,8 +175,14 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 		return err
 	}
 
-	// Try schema2 first
-	builder := schema2.NewManifestBuilder(p.repo.Blobs(ctx), p.config.ConfigMediaType, imgConfig)
+	// Try schema2 first, unless the layers are zstd-compressed: they can
+	// only be referenced by OCI manifests.
+	var builder distribution.ManifestBuilder
+	if compression == archive.Zstd {
+		builder = newOCIManifestBuilder(p.repo.Blobs(ctx), imgConfig)
+	} else {
+		builder = schema2.NewManifestBuilder(p.repo.Blobs(ctx), p.config.ConfigMediaType, imgConfig)
+	}
 	manifest, err := manifestFromBuilder(ctx, builder, descriptors)
 	if err != nil {
 		return err
@@ -181,6 +195,10 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 
 	putOptions := []distribution.ManifestServiceOption{distribution.WithTag(ref.Tag())}
 	if _, err = manSvc.Put(ctx, manifest, putOptions...); err != nil {
+		if compression == archive.Zstd {
+			logrus.Warnf("failed to upload OCI manifest: %v", err)
+			return err
+		}
 		if runtime.GOOS == "windows" || p.config.TrustKey == nil || p.config.RequireSchema2 {
 			logrus.Warnf("failed to upload schema2 manifest: %v", err)
 			return err
@@ -243,6 +261,7 @@ func manifestFromBuilder(ctx context.Context, builder distribution.ManifestBuild
 
 type v2PushDescriptor struct {
 	layer             PushLayer
+	compression       archive.Compression
 	v2MetadataService metadata.V2MetadataService
 	hmacKey           []byte
 	repoInfo          reference.Named
@@ -267,6 +286,14 @@ func (pd *v2PushDescriptor) DiffID() layer.DiffID {
 	return pd.layer.DiffID()
 }
 
+// mediaType returns the media type of the layer once compressed.
+func (pd *v2PushDescriptor) mediaType() string {
+	if pd.compression == archive.Zstd {
+		return image.MediaTypeLayerZstd
+	}
+	return schema2.MediaTypeLayer
+}
+
 func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.Output) (distribution.Descriptor, error) {
 	// Skip foreign layers unless this registry allows nondistributable artifacts.
 	if !pd.endpoint.AllowNondistributableArtifacts {
@@ -294,6 +321,8 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 
 	// Do we have any metadata associated with this layer's DiffID?
 	v2Metadata, err := pd.v2MetadataService.GetMetadata(diffID)
+	// blobs compressed differently cannot be reused
+	v2Metadata = filterV2MetadataByMediaType(pd.mediaType(), v2Metadata)
 	if err == nil {
 		// check for blob existence in the target repository
 		descriptor, exists, err := pd.layerAlreadyExists(ctx, progressOutput, diffID, true, 1, v2Metadata)
@@ -348,7 +377,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 		case distribution.ErrBlobMounted:
 			progress.Updatef(progressOutput, pd.ID(), "Mounted from %s", err.From.Name())
 
-			err.Descriptor.MediaType = schema2.MediaTypeLayer
+			err.Descriptor.MediaType = pd.mediaType()
 
 			pd.pushState.Lock()
 			pd.pushState.confirmedV2 = true
@@ -359,6 +388,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 			if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 				Digest:           err.Descriptor.Digest,
 				SourceRepository: pd.repoInfo.Name(),
+				MediaType:        metadataMediaType(err.Descriptor.MediaType),
 			}); err != nil {
 				return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 			}
@@ -445,15 +475,18 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 
 	reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, contentReader), progressOutput, size, pd.ID(), "Pushing")
 
+	var mediaType string
 	switch m := pd.layer.MediaType(); m {
 	case schema2.MediaTypeUncompressedLayer:
-		compressedReader, compressionDone := compress(reader)
+		compressedReader, compressionDone := compress(reader, pd.compression)
 		defer func(closer io.Closer) {
 			closer.Close()
 			<-compressionDone
 		}(reader)
 		reader = compressedReader
+		mediaType = pd.mediaType()
 	case schema2.MediaTypeLayer:
+		mediaType = m
 	default:
 		reader.Close()
 		return distribution.Descriptor{}, fmt.Errorf("unsupported layer media type %s", m)
@@ -480,13 +513,14 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 		Digest:           pushDigest,
 		SourceRepository: pd.repoInfo.Name(),
+		MediaType:        metadataMediaType(mediaType),
 	}); err != nil {
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 	}
 
 	desc := distribution.Descriptor{
 		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
+		MediaType: mediaType,
 		Size:      nn,
 	}
 
@@ -555,11 +589,12 @@ attempts:
 				if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 					Digest:           desc.Digest,
 					SourceRepository: pd.repoInfo.Name(),
+					MediaType:        metadataMediaType(pd.mediaType()),
 				}); err != nil {
 					return distribution.Descriptor{}, false, xfer.DoNotRetry{Err: err}
 				}
 			}
-			desc.MediaType = schema2.MediaTypeLayer
+			desc.MediaType = pd.mediaType()
 			exists = true
 			break attempts
 		case distribution.ErrBlobUnknown:
@@ -610,6 +645,33 @@ func getMaxMountAndExistenceCheckAttempts(layer PushLayer) (maxMountAttempts, ma
 	}
 }
 
+// filterV2MetadataByMediaType returns the metadata entries of blobs
+// compressed the same way as layers of the given media type.
+func filterV2MetadataByMediaType(mediaType string, v2Metadata []metadata.V2Metadata) []metadata.V2Metadata {
+	zstd := isZstdMediaType(mediaType)
+	filtered := []metadata.V2Metadata{}
+	for _, meta := range v2Metadata {
+		if isZstdMediaType(meta.MediaType) == zstd {
+			filtered = append(filtered, meta)
+		}
+	}
+	return filtered
+}
+
+func isZstdMediaType(mediaType string) bool {
+	return strings.HasSuffix(mediaType, "+zstd")
+}
+
+// metadataMediaType returns the media type to record in the metadata of a
+// blob of the given media type. It is empty for gzip-compressed blobs, so that
+// their metadata matches the one recorded by older daemons.
+func metadataMediaType(mediaType string) string {
+	if isZstdMediaType(mediaType) {
+		return mediaType
+	}
+	return ""
+}
+
 // getRepositoryMountCandidates returns an array of v2 metadata items belonging to the given registry. The
 // array is sorted from youngest to oldest. If requireRegistryMatch is true, the resulting array will contain
 // only metadata entries having registry part of SourceRepository matching the part of repoInfo.
@@ -2,6 +2,7 @@ package distribution // import "github.com/docker/docker/distribution"
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -13,11 +14,13 @@ import (
 	"github.com/docker/distribution/registry/api/errcode"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/progress"
 	refstore "github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 func TestGetRepositoryMountCandidates(t *testing.T) {
@@ -618,6 +621,63 @@ func TestPushRegistryWhenAuthInfoEmpty(t *testing.T) {
 	}
 }
 
+func TestFilterV2MetadataByMediaType(t *testing.T) {
+	gzipMeta := metadata.V2Metadata{Digest: digest.Digest("1"), SourceRepository: "docker.io/library/busybox"}
+	zstdMeta := metadata.V2Metadata{Digest: digest.Digest("2"), SourceRepository: "docker.io/library/busybox", MediaType: image.MediaTypeLayerZstd}
+	v2Metadata := []metadata.V2Metadata{gzipMeta, zstdMeta}
+
+	if filtered := filterV2MetadataByMediaType(schema2.MediaTypeLayer, v2Metadata); !reflect.DeepEqual(filtered, []metadata.V2Metadata{gzipMeta}) {
+		t.Fatalf("unexpected metadata for gzip layers: %v", filtered)
+	}
+	if filtered := filterV2MetadataByMediaType(image.MediaTypeLayerZstd, v2Metadata); !reflect.DeepEqual(filtered, []metadata.V2Metadata{zstdMeta}) {
+		t.Fatalf("unexpected metadata for zstd layers: %v", filtered)
+	}
+}
+
+func TestOCIManifestBuilder(t *testing.T) {
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	configDigest := digest.FromBytes(config)
+	repo := &mockRepo{
+		t:     t,
+		blobs: map[digest.Digest]distribution.Descriptor{configDigest: {Digest: configDigest, Size: int64(len(config))}},
+	}
+
+	builder := newOCIManifestBuilder(repo.Blobs(context.Background()), config)
+	for _, d := range []distribution.Descriptor{
+		{Digest: digest.FromString("zstd layer"), MediaType: image.MediaTypeLayerZstd},
+		{Digest: digest.FromString("gzip layer"), MediaType: schema2.MediaTypeLayer},
+	} {
+		if err := builder.AppendReference(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, err := builder.Build(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mediaType, payload, err := m.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != ocispec.MediaTypeImageManifest {
+		t.Fatalf("unexpected manifest media type %s", mediaType)
+	}
+	var parsed schema2.Manifest
+	if err := json.Unmarshal(payload, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.MediaType != ocispec.MediaTypeImageManifest || parsed.SchemaVersion != 2 {
+		t.Fatalf("unexpected manifest version: %+v", parsed.Versioned)
+	}
+	if parsed.Config.MediaType != ocispec.MediaTypeImageConfig || parsed.Config.Digest != configDigest {
+		t.Fatalf("unexpected config descriptor: %+v", parsed.Config)
+	}
+	if len(parsed.Layers) != 2 || parsed.Layers[0].MediaType != image.MediaTypeLayerZstd || parsed.Layers[1].MediaType != ocispec.MediaTypeImageLayerGzip {
+		t.Fatalf("unexpected layers: %+v", parsed.Layers)
+	}
+}
+
 func taggedMetadata(key string, dgst string, sourceRepo string) metadata.V2Metadata {
 	meta := metadata.V2Metadata{
 		Digest:           digest.Digest(dgst),
@@ -11,7 +11,8 @@ swagger generate model -f api/swagger.yaml \
     -n Plugin -n PluginDevice -n PluginMount -n PluginEnv -n PluginInterfaceType \
     -n Port \
     -n ServiceUpdateResponse \
-    -n Volume
+    -n Volume \
+    -n VolumeReference
 
 swagger generate operation -f api/swagger.yaml \
     -t api -a types -m types -C api/swagger-gen.yaml \
@@ -23,5 +24,6 @@ swagger generate operation -f api/swagger.yaml \
     -n ContainerUpdate \
     -n ContainerWait \
     -n ImageHistory \
+    -n VolumeClone \
     -n VolumeCreate \
     -n VolumeList
@@ -14,6 +14,13 @@ import (
 	"github.com/opencontainers/go-digest"
 )
 
+// Media types of zstd-compressed layers, which are not defined by the
+// vendored image-spec yet.
+const (
+	MediaTypeLayerZstd                 = "application/vnd.oci.image.layer.v1.tar+zstd"
+	MediaTypeLayerNonDistributableZstd = "application/vnd.oci.image.layer.nondistributable.v1.tar+zstd"
+)
+
 // ID is the content-addressable ID of an image.
 type ID digest.Digest
 
@@ -212,7 +219,10 @@ func NewHistory(author, comment, createdBy string, isEmptyLayer bool) History {
 type Exporter interface {
 	Load(io.ReadCloser, io.Writer, bool) error
 	// TODO: Load(net.Context, io.ReadCloser, <- chan StatusMessage) error
-	Save([]string, io.Writer) error
+	// Save writes the images to the writer in the given format. Layers
+	// are compressed with the given compression, which is only supported
+	// by the "oci" format.
+	Save(names []string, format, compression string, outStream io.Writer) error
 }
 
 // NewFromJSON creates an Image configuration from json.
@@ -49,18 +49,25 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	if err != nil {
 		return err
 	}
+	var manifest []manifestItem
 	manifestFile, err := os.Open(manifestPath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		// no manifest, load the OCI image layout if there is one
+		manifest, err = ociManifest(tmpDir)
+		if err != nil {
+			return err
+		}
+		if manifest == nil {
 			return l.legacyLoad(tmpDir, outStream, progressOutput)
 		}
-		return err
-	}
-	defer manifestFile.Close()
-
-	var manifest []manifestItem
-	if err := json.NewDecoder(manifestFile).Decode(&manifest); err != nil {
-		return err
+	} else {
+		defer manifestFile.Close()
+		if err := json.NewDecoder(manifestFile).Decode(&manifest); err != nil {
+			return err
+		}
 	}
 
 	var parentLinks []parentLink
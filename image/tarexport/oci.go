@@ -0,0 +1,422 @@
+package tarexport // import "github.com/docker/docker/image/tarexport"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/system"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// saveOCI writes the images as an OCI image layout. A manifest.json
+// referencing the blobs of the layout is included as well, so that the
+// archive can still be loaded by daemons which don't support OCI layouts.
+func (s *saveSession) saveOCI(outStream io.Writer) error {
+	s.ociLayers = make(map[layer.DiffID]ocispec.Descriptor)
+
+	tempDir, err := ioutil.TempDir("", "docker-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	s.outDir = tempDir
+	if err := os.MkdirAll(filepath.Join(tempDir, ociBlobsDir, string(digest.Canonical)), 0755); err != nil {
+		return err
+	}
+
+	index := ocispec.Index{Versioned: specs.Versioned{SchemaVersion: 2}}
+	var manifest []manifestItem
+	var parentLinks []parentLink
+
+	for id, imageDescr := range s.images {
+		manifestDesc, item, err := s.saveOCIImage(id)
+		if err != nil {
+			return err
+		}
+
+		if len(imageDescr.refs) == 0 {
+			index.Manifests = append(index.Manifests, manifestDesc)
+		}
+		for _, ref := range imageDescr.refs {
+			desc := manifestDesc
+			desc.Annotations = map[string]string{
+				ociImageNameAnnotation:    ref.String(),
+				ocispec.AnnotationRefName: ref.Tag(),
+			}
+			index.Manifests = append(index.Manifests, desc)
+			item.RepoTags = append(item.RepoTags, reference.FamiliarString(ref))
+		}
+		manifest = append(manifest, item)
+
+		parentID, _ := s.is.GetParent(id)
+		parentLinks = append(parentLinks, parentLink{id, parentID})
+		s.tarexporter.loggerImgEvent.LogImageEvent(id.String(), id.String(), "save")
+	}
+
+	for i, p := range validatedParentLinks(parentLinks) {
+		if p.parentID != "" {
+			manifest[i].Parent = p.parentID
+		}
+	}
+
+	for name, v := range map[string]interface{}{
+		ocispec.ImageLayoutFile: ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion},
+		ociIndexFileName:        index,
+		manifestFileName:        manifest,
+	} {
+		if err := writeJSONFile(filepath.Join(tempDir, name), v); err != nil {
+			return err
+		}
+	}
+
+	fs, err := archive.Tar(tempDir, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	_, err = io.Copy(outStream, fs)
+	return err
+}
+
+// saveOCIImage writes the config, layers and manifest of an image as blobs,
+// and returns the descriptor of the manifest and the matching manifest.json
+// entry.
+func (s *saveSession) saveOCIImage(id image.ID) (ocispec.Descriptor, manifestItem, error) {
+	img := s.images[id].image
+	if len(img.RootFS.DiffIDs) == 0 {
+		return ocispec.Descriptor{}, manifestItem{}, fmt.Errorf("empty export - not implemented")
+	}
+
+	configDesc, err := s.writeBlob(ocispec.MediaTypeImageConfig, img.RawJSON())
+	if err != nil {
+		return ocispec.Descriptor{}, manifestItem{}, err
+	}
+	item := manifestItem{Config: blobPath(configDesc.Digest)}
+
+	operatingSystem := img.OS
+	if operatingSystem == "" {
+		operatingSystem = runtime.GOOS
+	}
+
+	var layers []ocispec.Descriptor
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+	for _, diffID := range img.RootFS.DiffIDs {
+		rootFS.Append(diffID)
+		desc, src, err := s.saveOCILayer(rootFS.ChainID(), operatingSystem)
+		if err != nil {
+			return ocispec.Descriptor{}, manifestItem{}, err
+		}
+		layers = append(layers, desc)
+		item.Layers = append(item.Layers, blobPath(desc.Digest))
+		if src.Digest != "" {
+			if item.LayerSources == nil {
+				item.LayerSources = make(map[layer.DiffID]distribution.Descriptor)
+			}
+			item.LayerSources[diffID] = src
+		}
+	}
+
+	m, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    configDesc,
+		Layers:    layers,
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, manifestItem{}, err
+	}
+	manifestDesc, err := s.writeBlob(ocispec.MediaTypeImageManifest, m)
+	if err != nil {
+		return ocispec.Descriptor{}, manifestItem{}, err
+	}
+	manifestDesc.Platform = &ocispec.Platform{
+		Architecture: img.Architecture,
+		OS:           operatingSystem,
+		OSVersion:    img.OSVersion,
+		OSFeatures:   img.OSFeatures,
+	}
+	return manifestDesc, item, nil
+}
+
+// saveOCILayer writes the tar of a layer as a blob, compressed with the
+// compression of the session. As long as the blob is uncompressed, its digest
+// is the DiffID of the layer.
+func (s *saveSession) saveOCILayer(id layer.ChainID, operatingSystem string) (ocispec.Descriptor, distribution.Descriptor, error) {
+	l, err := s.lss[operatingSystem].Get(id)
+	if err != nil {
+		return ocispec.Descriptor{}, distribution.Descriptor{}, err
+	}
+	defer layer.ReleaseAndLog(s.lss[operatingSystem], l)
+
+	var src distribution.Descriptor
+	if fs, ok := l.(distribution.Describable); ok {
+		src = fs.Descriptor()
+	}
+	if desc, exists := s.ociLayers[l.DiffID()]; exists {
+		return desc, src, nil
+	}
+
+	desc, err := s.writeLayerBlob(l)
+	if err != nil {
+		return ocispec.Descriptor{}, distribution.Descriptor{}, err
+	}
+	desc.MediaType = ociLayerMediaType(s.compression, src.Digest != "")
+	if src.Digest != "" {
+		desc.URLs = src.URLs
+	}
+	s.ociLayers[l.DiffID()] = desc
+	return desc, src, nil
+}
+
+// writeLayerBlob compresses the tar stream of a layer into the blobs
+// directory of the layout. The media type of the returned descriptor is left
+// for the caller to fill.
+func (s *saveSession) writeLayerBlob(l layer.Layer) (ocispec.Descriptor, error) {
+	// The digest of the blob is only known once it has been written, so
+	// write it to a temporary file first.
+	// Use system.TempFileSequential rather than ioutil.TempFile. This
+	// ensures sequential file access on Windows to avoid eating into MM
+	// standby list. On Linux, this equates to a regular ioutil.TempFile.
+	tmpFile, err := system.TempFileSequential(s.outDir, "layer-")
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
+
+	arch, err := l.TarStream()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer arch.Close()
+
+	digester := digest.Canonical.Digester()
+	w, err := archive.CompressStream(io.MultiWriter(tmpFile, digester.Hash()), s.compression)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if _, err := io.Copy(w, arch); err != nil {
+		w.Close()
+		return ocispec.Descriptor{}, err
+	}
+	if err := w.Close(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	desc := ocispec.Descriptor{Digest: digester.Digest()}
+	blob := filepath.Join(s.outDir, filepath.FromSlash(blobPath(desc.Digest)))
+	if err := os.Rename(tmpFile.Name(), blob); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := system.Chtimes(blob, time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	fi, err := os.Stat(blob)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc.Size = fi.Size()
+	return desc, nil
+}
+
+// ociLayerMediaType returns the media type of a layer blob compressed with
+// compression.
+func ociLayerMediaType(compression archive.Compression, nonDistributable bool) string {
+	switch compression {
+	case archive.Gzip:
+		if nonDistributable {
+			return ocispec.MediaTypeImageLayerNonDistributableGzip
+		}
+		return ocispec.MediaTypeImageLayerGzip
+	case archive.Zstd:
+		if nonDistributable {
+			return image.MediaTypeLayerNonDistributableZstd
+		}
+		return image.MediaTypeLayerZstd
+	default:
+		if nonDistributable {
+			return ocispec.MediaTypeImageLayerNonDistributable
+		}
+		return ocispec.MediaTypeImageLayer
+	}
+}
+
+// writeBlob stores content in the blobs directory of the layout.
+func (s *saveSession) writeBlob(mediaType string, content []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	blob := filepath.Join(s.outDir, filepath.FromSlash(blobPath(desc.Digest)))
+	if err := ioutil.WriteFile(blob, content, 0644); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := system.Chtimes(blob, time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// blobPath returns the path of a blob relative to the root of the layout.
+// IMPORTANT: We use path, not filepath here to ensure the paths in the
+// manifest use Unix-style forward-slashes.
+func blobPath(dgst digest.Digest) string {
+	return path.Join(ociBlobsDir, string(dgst.Algorithm()), dgst.Hex())
+}
+
+func writeJSONFile(filename string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filename, b, 0644); err != nil {
+		return err
+	}
+	return system.Chtimes(filename, time.Unix(0, 0), time.Unix(0, 0))
+}
+
+// ociManifest converts the index of an OCI image layout extracted in dir to
+// manifest.json entries. It returns nil if dir has no OCI image layout.
+func ociManifest(dir string) ([]manifestItem, error) {
+	indexPath, err := safePath(dir, ociIndexFileName)
+	if err != nil {
+		return nil, err
+	}
+	var index ocispec.Index
+	if err := readJSONFile(indexPath, &index); err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var manifest []manifestItem
+	items := make(map[digest.Digest]int)
+	for _, desc := range index.Manifests {
+		desc, err := resolveOCIManifest(dir, desc)
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := items[desc.Digest]
+		if !ok {
+			item, err := ociManifestItem(dir, desc)
+			if err != nil {
+				return nil, err
+			}
+			i = len(manifest)
+			items[desc.Digest] = i
+			manifest = append(manifest, item)
+		}
+		if repoTag := ociRepoTag(desc.Annotations); repoTag != "" {
+			manifest[i].RepoTags = append(manifest[i].RepoTags, repoTag)
+		}
+	}
+	return manifest, nil
+}
+
+// resolveOCIManifest returns the descriptor of the manifest to load for an
+// entry of the index, selecting the manifest matching the platform of the
+// daemon in nested indexes.
+func resolveOCIManifest(dir string, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageManifest, images.MediaTypeDockerSchema2Manifest:
+		return desc, nil
+	case ocispec.MediaTypeImageIndex, images.MediaTypeDockerSchema2ManifestList:
+		var index ocispec.Index
+		if err := readBlob(dir, desc.Digest, &index); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		matcher := platforms.NewMatcher(platforms.DefaultSpec())
+		for _, m := range index.Manifests {
+			if m.Platform == nil || matcher.Match(*m.Platform) {
+				// Keep the annotations naming the image from the parent index.
+				if m.Annotations == nil {
+					m.Annotations = desc.Annotations
+				}
+				return resolveOCIManifest(dir, m)
+			}
+		}
+		return ocispec.Descriptor{}, fmt.Errorf("no manifest in %s matches platform %s", desc.Digest, platforms.Default())
+	default:
+		return ocispec.Descriptor{}, fmt.Errorf("unsupported media type %q in OCI image index", desc.MediaType)
+	}
+}
+
+func ociManifestItem(dir string, desc ocispec.Descriptor) (manifestItem, error) {
+	var m ocispec.Manifest
+	if err := readBlob(dir, desc.Digest, &m); err != nil {
+		return manifestItem{}, err
+	}
+	item := manifestItem{Config: blobPath(m.Config.Digest)}
+	for _, l := range m.Layers {
+		item.Layers = append(item.Layers, blobPath(l.Digest))
+	}
+	return item, nil
+}
+
+// ociRepoTag returns the image reference named by the annotations of a
+// manifest descriptor, or an empty string if it doesn't name a tagged image.
+func ociRepoTag(annotations map[string]string) string {
+	for _, name := range []string{annotations[ociImageNameAnnotation], annotations[ocispec.AnnotationRefName]} {
+		if name == "" {
+			continue
+		}
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			continue
+		}
+		if tagged, ok := named.(reference.NamedTagged); ok {
+			return reference.FamiliarString(tagged)
+		}
+	}
+	return ""
+}
+
+func readBlob(dir string, dgst digest.Digest, v interface{}) error {
+	if err := dgst.Validate(); err != nil {
+		return err
+	}
+	blob, err := safePath(dir, blobPath(dgst))
+	if err != nil {
+		return err
+	}
+	return readJSONFile(blob, v)
+}
+
+func readJSONFile(filename string, v interface{}) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		return errors.Wrapf(err, "error decoding %s", filepath.Base(filename))
+	}
+	return nil
+}
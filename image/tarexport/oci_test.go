@@ -0,0 +1,91 @@
+package tarexport // import "github.com/docker/docker/image/tarexport"
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/platforms"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func writeTestBlob(t *testing.T, dir string, mediaType string, v interface{}) ocispec.Descriptor {
+	b, err := json.Marshal(v)
+	assert.NilError(t, err)
+	desc := ocispec.Descriptor{MediaType: mediaType, Digest: digest.FromBytes(b), Size: int64(len(b))}
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, ociBlobsDir, "sha256"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(blobPath(desc.Digest))), b, 0644))
+	return desc
+}
+
+func TestOCIManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oci-layout")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	config := writeTestBlob(t, dir, ocispec.MediaTypeImageConfig, map[string]string{"architecture": "amd64"})
+	layer := digest.FromString("layer")
+	manifest := writeTestBlob(t, dir, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    []ocispec.Descriptor{{MediaType: ocispec.MediaTypeImageLayerGzip, Digest: layer}},
+	})
+	other := writeTestBlob(t, dir, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    ocispec.Descriptor{Digest: digest.FromString("other")},
+	})
+	platform := platforms.DefaultSpec()
+	nested := writeTestBlob(t, dir, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []ocispec.Descriptor{
+			{MediaType: other.MediaType, Digest: other.Digest, Platform: &ocispec.Platform{OS: "plan9", Architecture: "mips"}},
+			{MediaType: manifest.MediaType, Digest: manifest.Digest, Platform: &platform},
+		},
+	})
+
+	tagged := manifest
+	tagged.Annotations = map[string]string{ociImageNameAnnotation: "docker.io/library/busybox:latest", ocispec.AnnotationRefName: "latest"}
+	refOnly := manifest
+	refOnly.Annotations = map[string]string{ocispec.AnnotationRefName: "example.com/foo:1.0"}
+	nested.Annotations = map[string]string{ocispec.AnnotationRefName: "multi:2.0"}
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []ocispec.Descriptor{tagged, refOnly, nested},
+	}
+	b, err := json.Marshal(index)
+	assert.NilError(t, err)
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, ociIndexFileName), b, 0644))
+
+	items, err := ociManifest(dir)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(items, 1))
+	assert.Check(t, is.Equal(blobPath(config.Digest), items[0].Config))
+	assert.Check(t, is.DeepEqual([]string{blobPath(layer)}, items[0].Layers))
+	assert.Check(t, is.DeepEqual([]string{"busybox:latest", "example.com/foo:1.0", "multi:2.0"}, items[0].RepoTags))
+}
+
+func TestOCIManifestNoLayout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oci-layout")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	items, err := ociManifest(dir)
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(items))
+}
+
+func TestOCILayerMediaType(t *testing.T) {
+	assert.Check(t, is.Equal(ocispec.MediaTypeImageLayer, ociLayerMediaType(archive.Uncompressed, false)))
+	assert.Check(t, is.Equal(ocispec.MediaTypeImageLayerGzip, ociLayerMediaType(archive.Gzip, false)))
+	assert.Check(t, is.Equal(image.MediaTypeLayerZstd, ociLayerMediaType(archive.Zstd, false)))
+	assert.Check(t, is.Equal(ocispec.MediaTypeImageLayerNonDistributable, ociLayerMediaType(archive.Uncompressed, true)))
+	assert.Check(t, is.Equal(image.MediaTypeLayerNonDistributableZstd, ociLayerMediaType(archive.Zstd, true)))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/system"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
@@ -31,13 +32,37 @@ type imageDescriptor struct {
 
 type saveSession struct {
 	*tarexporter
+	format      string
+	compression archive.Compression
 	outDir      string
 	images      map[image.ID]*imageDescriptor
 	savedLayers map[string]struct{}
 	diffIDPaths map[layer.DiffID]string // cache every diffID blob to avoid duplicates
+	ociLayers   map[layer.DiffID]ocispec.Descriptor
 }
 
-func (l *tarexporter) Save(names []string, outStream io.Writer) error {
+func (l *tarexporter) Save(names []string, format, compression string, outStream io.Writer) error {
+	switch format {
+	case "", FormatDocker, FormatOCI:
+	default:
+		return errors.Errorf("unsupported image format: %s", format)
+	}
+
+	var layerCompression archive.Compression
+	switch compression {
+	case "", CompressionNone:
+		layerCompression = archive.Uncompressed
+	case CompressionGzip:
+		layerCompression = archive.Gzip
+	case CompressionZstd:
+		layerCompression = archive.Zstd
+	default:
+		return errors.Errorf("unsupported layer compression: %s", compression)
+	}
+	if layerCompression != archive.Uncompressed && format != FormatOCI {
+		return errors.Errorf("layer compression is not supported by the %q format", format)
+	}
+
 	images, err := l.parseNames(names)
 	if err != nil {
 		return err
@@ -45,7 +70,11 @@ func (l *tarexporter) Save(names []string, outStream io.Writer) error {
 
 	// Release all the image top layer references
 	defer l.releaseLayerReferences(images)
-	return (&saveSession{tarexporter: l, images: images}).save(outStream)
+	s := &saveSession{tarexporter: l, format: format, compression: layerCompression, images: images}
+	if format == FormatOCI {
+		return s.saveOCI(outStream)
+	}
+	return s.save(outStream)
 }
 
 // parseNames will parse the image names to a map which contains image.ID to *imageDescriptor.
@@ -13,6 +13,29 @@ const (
 	legacyConfigFileName       = "json"
 	legacyVersionFileName      = "VERSION"
 	legacyRepositoriesFileName = "repositories"
+	ociIndexFileName           = "index.json"
+	ociBlobsDir                = "blobs"
+
+	// ociImageNameAnnotation is the annotation containerd uses to store the
+	// full reference of an image in an index.
+	ociImageNameAnnotation = "io.containerd.image.name"
+)
+
+// Formats images can be saved in.
+const (
+	// FormatDocker is the legacy Docker archive format, with a manifest.json
+	// and a directory per layer.
+	FormatDocker = "docker"
+	// FormatOCI is the OCI image layout, with an index.json and a blobs
+	// directory. A manifest.json is also included for compatibility.
+	FormatOCI = "oci"
+)
+
+// Compressions of the layers of saved images.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
 )
 
 type manifestItem struct {
@@ -438,9 +438,16 @@ func (s *DockerDaemonSuite) TestDaemonEvents(c *check.C) {
 		" cluster-store=, ",
 		" cluster-store-opts=",
 		" debug=true, ",
+		" default-cgroupns-mode=",
 		" default-ipc-mode=",
 		" default-runtime=",
 		" default-shm-size=",
+		" gc-build-cache-keep-storage=0, ",
+		" gc-interval=1h0m0s, ",
+		" gc-max-age=0, ",
+		" gc-max-images-size=0, ",
+		" gc-min-age=0, ",
+		" gc-min-free-space=0, ",
 		" insecure-registries=[",
 		" labels=[\"bar=foo\"], ",
 		" live-restore=",
@@ -4,14 +4,17 @@ import (
 	"context"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
 	"github.com/docker/docker/integration/internal/container"
 	"github.com/docker/docker/internal/test/request"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
+	"gotest.tools/poll"
 	"gotest.tools/skip"
 )
 
@@ -48,3 +51,82 @@ func TestExec(t *testing.T) {
 	assert.Assert(t, is.Contains(out, "PWD=/tmp"), "exec command not running in expected /tmp working directory")
 	assert.Assert(t, is.Contains(out, "FOO=BAR"), "exec command not running with expected environment variable FOO")
 }
+
+func TestExecKillAndWait(t *testing.T) {
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "exec kill and wait are not supported")
+	skip.If(t, testEnv.OSType == "windows")
+	defer setupTest(t)()
+	ctx := context.Background()
+	client := request.NewAPIClient(t)
+
+	cID := container.Run(t, ctx, client)
+
+	id, err := client.ContainerExecCreate(ctx, cID, types.ExecConfig{
+		Cmd: strslice.StrSlice([]string{"sleep", "60"}),
+	})
+	assert.NilError(t, err)
+	err = client.ContainerExecStart(ctx, id.ID, types.ExecStartCheck{Detach: true})
+	assert.NilError(t, err)
+
+	execs, err := client.ContainerExecList(ctx, cID)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(execs, 1))
+	assert.Check(t, is.Equal(id.ID, execs[0].ExecID))
+
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	waitC := make(chan int64, 1)
+	go func() {
+		resp, err := client.ContainerExecWait(waitCtx, id.ID)
+		assert.Check(t, err)
+		waitC <- resp.StatusCode
+	}()
+
+	poll.WaitOn(t, execRunning(ctx, client, id.ID), poll.WithDelay(100*time.Millisecond))
+	err = client.ContainerExecKill(ctx, id.ID, "SIGTERM")
+	assert.NilError(t, err)
+
+	select {
+	case status := <-waitC:
+		assert.Check(t, is.Equal(int64(143), status))
+	case <-waitCtx.Done():
+		t.Fatal("timeout waiting for the exec to exit")
+	}
+}
+
+func TestExecTimeout(t *testing.T) {
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "exec timeout is not supported")
+	skip.If(t, testEnv.OSType == "windows")
+	defer setupTest(t)()
+	ctx := context.Background()
+	client := request.NewAPIClient(t)
+
+	cID := container.Run(t, ctx, client)
+
+	id, err := client.ContainerExecCreate(ctx, cID, types.ExecConfig{
+		Cmd:     strslice.StrSlice([]string{"sleep", "60"}),
+		Timeout: time.Second,
+	})
+	assert.NilError(t, err)
+	err = client.ContainerExecStart(ctx, id.ID, types.ExecStartCheck{Detach: true})
+	assert.NilError(t, err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	resp, err := client.ContainerExecWait(waitCtx, id.ID)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(int64(143), resp.StatusCode))
+}
+
+func execRunning(ctx context.Context, client client.APIClient, execID string) func(log poll.LogT) poll.Result {
+	return func(log poll.LogT) poll.Result {
+		inspect, err := client.ContainerExecInspect(ctx, execID)
+		if err != nil {
+			return poll.Error(err)
+		}
+		if inspect.Running && inspect.Pid != 0 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for exec %s to be running", execID)
+	}
+}
@@ -0,0 +1,38 @@
+package container // import "github.com/docker/docker/integration/container"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestContainersPruneDryRun(t *testing.T) {
+	defer setupTest(t)()
+	ctx := context.Background()
+	client := request.NewAPIClient(t)
+
+	cID := container.Create(t, ctx, client, func(c *container.TestContainerConfig) {
+		c.Config.Labels = map[string]string{"test-containers-prune-dry-run": ""}
+	})
+
+	pruneFilters := filters.NewArgs(filters.Arg("label", "test-containers-prune-dry-run"))
+	report, err := client.ContainersPruneDryRun(ctx, pruneFilters)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{cID}, report.ContainersDeleted))
+
+	// Nothing was deleted.
+	_, err = client.ContainerInspect(ctx, cID)
+	assert.NilError(t, err)
+
+	report, err = client.ContainersPrune(ctx, pruneFilters)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{cID}, report.ContainersDeleted))
+
+	_, err = client.ContainerInspect(ctx, cID)
+	assert.Check(t, is.ErrorContains(err, "No such container"))
+}
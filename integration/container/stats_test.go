@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/integration/internal/container"
 	"github.com/docker/docker/internal/test/request"
 	"gotest.tools/assert"
@@ -41,3 +43,39 @@ func TestStats(t *testing.T) {
 	err = json.NewDecoder(resp.Body).Decode(&v)
 	assert.Assert(t, is.ErrorContains(err, ""), io.EOF)
 }
+
+func TestStatsMultipleContainers(t *testing.T) {
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "multiple containers stats added in 1.39")
+
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		cID := container.Run(t, ctx, client)
+		poll.WaitOn(t, container.IsInState(ctx, client, cID, "running"), poll.WithDelay(100*time.Millisecond))
+		ids = append(ids, cID)
+	}
+	// The third container is not matched by the filters.
+	container.Run(t, ctx, client)
+
+	resp, err := client.ContainersStats(ctx, filters.NewArgs(filters.Arg("id", ids[0]), filters.Arg("id", ids[1])), false)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+
+	seen := make(map[string]bool)
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var v types.StatsJSON
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		assert.Check(t, !v.Read.IsZero())
+		assert.Check(t, !v.PreRead.IsZero())
+		seen[v.ID] = true
+	}
+	assert.Check(t, is.DeepEqual(map[string]bool{ids[0]: true, ids[1]: true}, seen))
+}
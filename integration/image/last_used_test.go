@@ -0,0 +1,54 @@
+package image // import "github.com/docker/docker/integration/image"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/skip"
+)
+
+func TestImageLastUsed(t *testing.T) {
+	skip.If(t, testEnv.OSType == "windows", "FIXME")
+	defer setupTest(t)()
+	ctx := context.Background()
+	client := request.NewAPIClient(t)
+
+	img := "test-image-last-used"
+	cID := container.Create(t, ctx, client, container.WithCmd(""))
+	_, err := client.ContainerCommit(ctx, cID, types.ContainerCommitOptions{
+		Changes:   []string{`LABEL test-image-last-used=1`},
+		Reference: img,
+	})
+	assert.NilError(t, err)
+
+	resp, _, err := client.ImageInspectWithRaw(ctx, img)
+	assert.NilError(t, err)
+	assert.Check(t, resp.Metadata.LastUsedTime.IsZero())
+
+	// The image was never used, but was just tagged.
+	pruneFilters := filters.NewArgs(
+		filters.Arg("dangling", "false"),
+		filters.Arg("label", "test-image-last-used"),
+		filters.Arg("unused-for", "1h"),
+	)
+	report, err := client.ImagesPrune(ctx, pruneFilters)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(report.ImagesDeleted, 0))
+
+	container.Create(t, ctx, client, container.WithImage(img), container.WithCmd(""))
+
+	resp, _, err = client.ImageInspectWithRaw(ctx, img)
+	assert.NilError(t, err)
+	assert.Check(t, !resp.Metadata.LastUsedTime.IsZero())
+
+	images, err := client.ImageList(ctx, types.ImageListOptions{Filters: filters.NewArgs(filters.Arg("reference", img))})
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(images, 1))
+	assert.Check(t, is.Equal(resp.Metadata.LastUsedTime.Unix(), images[0].LastUsed))
+}
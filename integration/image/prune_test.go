@@ -0,0 +1,62 @@
+package image // import "github.com/docker/docker/integration/image"
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/integration/internal/container"
+	"github.com/docker/docker/internal/test/request"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+	"gotest.tools/skip"
+)
+
+func TestImagesPruneDryRun(t *testing.T) {
+	skip.If(t, testEnv.OSType == "windows", "FIXME")
+	defer setupTest(t)()
+	ctx := context.Background()
+	client := request.NewAPIClient(t)
+
+	img := "test-images-prune-dry-run"
+	cID := container.Create(t, ctx, client, container.WithCmd(""))
+	commitResp, err := client.ContainerCommit(ctx, cID, types.ContainerCommitOptions{
+		Changes:   []string{`LABEL test-images-prune-dry-run=1`},
+		Reference: img,
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, client.ContainerRemove(ctx, cID, types.ContainerRemoveOptions{Force: true}))
+
+	pruneFilters := filters.NewArgs(
+		filters.Arg("dangling", "false"),
+		filters.Arg("label", "test-images-prune-dry-run"),
+	)
+	dryRunReport, err := client.ImagesPruneDryRun(ctx, pruneFilters)
+	assert.NilError(t, err)
+	assert.Check(t, is.Contains(dryRunReport.ImagesDeleted, types.ImageDeleteResponseItem{Untagged: img + ":latest"}))
+	assert.Check(t, is.Contains(dryRunReport.ImagesDeleted, types.ImageDeleteResponseItem{Deleted: commitResp.ID}))
+
+	// Nothing was deleted.
+	_, _, err = client.ImageInspectWithRaw(ctx, img)
+	assert.NilError(t, err)
+
+	report, err := client.ImagesPrune(ctx, pruneFilters)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(sortedRecords(dryRunReport.ImagesDeleted), sortedRecords(report.ImagesDeleted)))
+	assert.Check(t, is.Equal(dryRunReport.SpaceReclaimed, report.SpaceReclaimed))
+
+	_, _, err = client.ImageInspectWithRaw(ctx, img)
+	assert.Check(t, is.ErrorContains(err, "No such image:"))
+}
+
+func sortedRecords(records []types.ImageDeleteResponseItem) []types.ImageDeleteResponseItem {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Deleted != records[j].Deleted {
+			return records[i].Deleted < records[j].Deleted
+		}
+		return records[i].Untagged < records[j].Untagged
+	})
+	return records
+}
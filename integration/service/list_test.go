@@ -0,0 +1,63 @@
+package service // import "github.com/docker/docker/integration/service"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/integration/internal/swarm"
+	"gotest.tools/assert"
+	"gotest.tools/poll"
+	"gotest.tools/skip"
+)
+
+func TestServiceListWithStatuses(t *testing.T) {
+	skip.If(t, testEnv.IsRemoteDaemon())
+	skip.If(t, testEnv.OSType == "windows")
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "status option is not supported")
+	defer setupTest(t)()
+	d := swarm.NewSwarm(t, testEnv)
+	defer d.Stop(t)
+	client := d.NewClientT(t)
+	defer client.Close()
+
+	id := swarm.CreateService(t, d,
+		swarm.ServiceWithName("test-service-list-status"),
+		swarm.ServiceWithReplicas(2),
+	)
+	poll.WaitOn(t, serviceRunningTasks(client, id, 2), swarm.ServicePoll)
+
+	filter := filters.NewArgs(filters.Arg("id", id))
+	services, err := client.ServiceList(context.Background(), types.ServiceListOptions{Filters: filter, Status: true})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(services))
+	assert.Assert(t, services[0].ServiceStatus != nil)
+	assert.Equal(t, uint64(2), services[0].ServiceStatus.DesiredTasks)
+
+	services, err = client.ServiceList(context.Background(), types.ServiceListOptions{Filters: filter})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(services))
+	assert.Assert(t, services[0].ServiceStatus == nil)
+}
+
+func serviceRunningTasks(client client.ServiceAPIClient, id string, count uint64) func(log poll.LogT) poll.Result {
+	return func(log poll.LogT) poll.Result {
+		services, err := client.ServiceList(context.Background(), types.ServiceListOptions{
+			Filters: filters.NewArgs(filters.Arg("id", id)),
+			Status:  true,
+		})
+		switch {
+		case err != nil:
+			return poll.Error(err)
+		case len(services) != 1 || services[0].ServiceStatus == nil:
+			return poll.Continue("waiting for the status of service %s", id)
+		case services[0].ServiceStatus.RunningTasks == count:
+			return poll.Success()
+		default:
+			return poll.Continue("running tasks at %d waiting for %d", services[0].ServiceStatus.RunningTasks, count)
+		}
+	}
+}
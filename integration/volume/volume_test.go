@@ -1,17 +1,24 @@
 package volume
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
 	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
 	"github.com/docker/docker/integration/internal/container"
 	"github.com/docker/docker/internal/test/request"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
@@ -108,6 +115,141 @@ func TestVolumesInspect(t *testing.T) {
 	assert.Check(t, is.Equal(testCreatedAt.Equal(now), true), "Time Volume is CreatedAt not equal to current time")
 }
 
+func TestVolumesExportImport(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "FIXME")
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "export and import are not supported before API 1.39")
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	name := t.Name()
+	_, err := client.VolumeCreate(ctx, volumetypes.VolumeCreateBody{Name: name})
+	assert.NilError(t, err)
+
+	importData(ctx, t, client, name, "hello")
+
+	rdr, err := client.VolumeExport(ctx, name, "gzip")
+	assert.NilError(t, err)
+	defer rdr.Close()
+
+	// The volume cannot be removed while it is exported.
+	err = client.VolumeRemove(ctx, name, false)
+	assert.Check(t, is.ErrorContains(err, "volume is in use"))
+
+	assert.Check(t, is.Equal("hello", exportedData(t, rdr)))
+
+	err = client.VolumeImport(ctx, "no-such-volume", strings.NewReader(""))
+	assert.Check(t, is.ErrorContains(err, "no such volume"))
+}
+
+func TestVolumesClone(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "FIXME")
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "clone is not supported before API 1.39")
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	name := t.Name()
+	_, err := client.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Name:   name,
+		Labels: map[string]string{"seed": "true"},
+	})
+	assert.NilError(t, err)
+	importData(ctx, t, client, name, "hello")
+
+	clone, err := client.VolumeClone(ctx, name, volumetypes.VolumeCloneBody{Name: name + "-clone"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(name+"-clone", clone.Name))
+	assert.Check(t, is.Equal("local", clone.Driver))
+	assert.Check(t, is.DeepEqual(map[string]string{"seed": "true"}, clone.Labels))
+
+	// The clone is independent from the volume.
+	importData(ctx, t, client, name, "world")
+
+	rdr, err := client.VolumeExport(ctx, clone.Name, "")
+	assert.NilError(t, err)
+	defer rdr.Close()
+	assert.Check(t, is.Equal("hello", exportedData(t, rdr)))
+
+	_, err = client.VolumeClone(ctx, name, volumetypes.VolumeCloneBody{Name: clone.Name})
+	assert.Check(t, is.ErrorContains(err, "already exists"))
+}
+
+func TestVolumesReferences(t *testing.T) {
+	skip.If(t, versions.LessThan(testEnv.DaemonAPIVersion(), "1.39"), "references are not supported before API 1.39")
+	defer setupTest(t)()
+	client := request.NewAPIClient(t)
+	ctx := context.Background()
+
+	prefix, slash := getPrefixAndSlashFromDaemonPlatform()
+
+	name := t.Name()
+	_, err := client.VolumeCreate(ctx, volumetypes.VolumeCreateBody{Name: name})
+	assert.NilError(t, err)
+	_, err = client.VolumeCreate(ctx, volumetypes.VolumeCreateBody{Name: name + "-unused"})
+	assert.NilError(t, err)
+
+	cName := strings.ToLower(name) + "-container"
+	id := container.Create(t, ctx, client, container.WithName(cName), container.WithBind(name, prefix+slash+"data"))
+
+	expected := []types.VolumeReference{{ContainerID: id, ContainerName: cName, Destination: prefix + slash + "data"}}
+
+	vol, err := client.VolumeInspect(ctx, name)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(expected, vol.References))
+
+	volumes, err := client.VolumeList(ctx, filters.NewArgs(filters.Arg("referenced-by", cName)))
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(volumes.Volumes, 1))
+	assert.Check(t, is.Equal(name, volumes.Volumes[0].Name))
+	assert.Check(t, is.DeepEqual(expected, volumes.Volumes[0].References))
+
+	err = client.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true})
+	assert.NilError(t, err)
+
+	vol, err = client.VolumeInspect(ctx, name)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(vol.References, 0))
+}
+
+// importData imports a file named "data", with the given content, to the
+// volume.
+func importData(ctx context.Context, t *testing.T, client client.APIClient, name, content string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: "data", Mode: 0644, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+
+	err = client.VolumeImport(ctx, name, &buf)
+	assert.NilError(t, err)
+}
+
+// exportedData returns the content of the file named "data" of an exported
+// volume.
+func exportedData(t *testing.T, rdr io.Reader) string {
+	t.Helper()
+
+	dr, err := archive.DecompressStream(rdr)
+	assert.NilError(t, err)
+	defer dr.Close()
+
+	tr := tar.NewReader(dr)
+	for {
+		hdr, err := tr.Next()
+		assert.NilError(t, err, "data not found in the exported archive")
+		if hdr.Name != "data" {
+			continue
+		}
+		b, err := ioutil.ReadAll(tr)
+		assert.NilError(t, err)
+		return string(b)
+	}
+}
+
 func getPrefixAndSlashFromDaemonPlatform() (prefix, slash string) {
 	if testEnv.OSType == "windows" {
 		return "c:", `\`
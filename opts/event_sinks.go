@@ -0,0 +1,159 @@
+package opts // import "github.com/docker/docker/opts"
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
+)
+
+// Types of sinks events can be forwarded to.
+const (
+	EventSinkWebhook = "webhook"
+	EventSinkUnix    = "unix"
+	EventSinkFile    = "file"
+)
+
+// EventSinkConfig is the configuration of an event sink.
+type EventSinkConfig struct {
+	// Type is the type of the sink: "webhook", "unix" or "file".
+	Type string `json:"type"`
+	// Address is the URL of the webhook, the path of the unix socket,
+	// or the path of the file.
+	Address string `json:"address"`
+	// Filters restricts the forwarded events, using the same
+	// "key=value" filters as the events API.
+	Filters []string `json:"filters,omitempty"`
+	// MaxRetries is the number of times a failed write is retried before
+	// the event is dropped. 0 means the default.
+	MaxRetries int `json:"max-retries,omitempty"`
+}
+
+// Validate checks the configuration of the sink.
+func (c *EventSinkConfig) Validate() error {
+	switch c.Type {
+	case EventSinkWebhook:
+		u, err := url.Parse(c.Address)
+		if err != nil {
+			return errors.Wrap(err, "invalid webhook event sink address")
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid webhook event sink address %q: an absolute http or https URL is required", c.Address)
+		}
+	case EventSinkUnix, EventSinkFile:
+		if c.Address == "" {
+			return errors.Errorf("missing address for %s event sink", c.Type)
+		}
+	case "":
+		return errors.New("missing event sink type")
+	default:
+		return errors.Errorf("unknown event sink type %q", c.Type)
+	}
+	if c.MaxRetries < 0 {
+		return errors.Errorf("invalid max-retries for event sink: %d", c.MaxRetries)
+	}
+	_, err := c.FilterArgs()
+	return err
+}
+
+// FilterArgs returns the filters of the sink as filter arguments.
+func (c *EventSinkConfig) FilterArgs() (filters.Args, error) {
+	args := filters.NewArgs()
+	for _, f := range c.Filters {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return args, errors.Errorf("invalid event sink filter %q: must be a key=value pair", f)
+		}
+		args.Add(kv[0], kv[1])
+	}
+	return args, nil
+}
+
+// EventSinksOpt is a Value type for parsing the event sinks from the command
+// line and from the daemon configuration file.
+type EventSinksOpt struct {
+	values []EventSinkConfig
+}
+
+// Name returns the name of the option in the daemon configuration file.
+func (o *EventSinksOpt) Name() string {
+	return "event-sinks"
+}
+
+// UnmarshalJSON fills the sinks from JSON input.
+func (o *EventSinksOpt) UnmarshalJSON(raw []byte) error {
+	var values []EventSinkConfig
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return err
+	}
+	for i := range values {
+		if err := values[i].Validate(); err != nil {
+			return err
+		}
+	}
+	o.values = values
+	return nil
+}
+
+// Set parses a sink from a comma-separated list of key=value pairs,
+// for example "type=webhook,address=http://example.com/,filter=type=container".
+func (o *EventSinksOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	var sink EventSinkConfig
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		switch key := strings.ToLower(parts[0]); key {
+		case "type":
+			sink.Type = strings.ToLower(parts[1])
+		case "address":
+			sink.Address = parts[1]
+		case "filter":
+			sink.Filters = append(sink.Filters, parts[1])
+		case "max-retries":
+			if sink.MaxRetries, err = strconv.Atoi(parts[1]); err != nil {
+				return fmt.Errorf("invalid max-retries value: %q (must be integer): %v", parts[1], err)
+			}
+		default:
+			return fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+	if err := sink.Validate(); err != nil {
+		return err
+	}
+
+	o.values = append(o.values, sink)
+	return nil
+}
+
+// Type returns the type of this option.
+func (o *EventSinksOpt) Type() string {
+	return "sink-options"
+}
+
+// String returns a string repr of this option.
+func (o *EventSinksOpt) String() string {
+	var sinks []string
+	for _, s := range o.values {
+		sinks = append(sinks, fmt.Sprintf("%s %s", s.Type, s.Address))
+	}
+	return strings.Join(sinks, ", ")
+}
+
+// Value returns the sinks.
+func (o *EventSinksOpt) Value() []EventSinkConfig {
+	return o.values
+}
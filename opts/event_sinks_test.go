@@ -0,0 +1,33 @@
+package opts // import "github.com/docker/docker/opts"
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestEventSinksOptSet(t *testing.T) {
+	var o EventSinksOpt
+	assert.NilError(t, o.Set("type=webhook,address=http://127.0.0.1/events,filter=type=container,filter=event=die,max-retries=3"))
+	assert.NilError(t, o.Set("type=file,address=/var/log/docker-events.log"))
+	assert.Assert(t, is.Len(o.Value(), 2))
+	assert.Check(t, is.DeepEqual(EventSinkConfig{
+		Type:       EventSinkWebhook,
+		Address:    "http://127.0.0.1/events",
+		Filters:    []string{"type=container", "event=die"},
+		MaxRetries: 3,
+	}, o.Value()[0]))
+
+	for _, value := range []string{
+		"address=/tmp/events",
+		"type=webhook,address=/tmp/events",
+		"type=unix",
+		"type=file,address=/tmp/events,filter=container",
+		"type=file,address=/tmp/events,max-retries=-1",
+		"type=file,address=/tmp/events,format=xml",
+		"type=syslog,address=/dev/log",
+	} {
+		assert.Check(t, o.Set(value) != nil, value)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/docker/go-units"
 )
@@ -335,3 +336,48 @@ func (m *MemBytes) UnmarshalJSON(s []byte) error {
 	*m = MemBytes(val)
 	return err
 }
+
+// Duration is a time.Duration that is set from, and unmarshaled from, a
+// human readable duration such as "1h30m"
+type Duration time.Duration
+
+// String returns the string format of the duration
+func (d *Duration) String() string {
+	// Return "0" for a zero duration so that the default value is hidden,
+	// as for MemBytes.
+	if d.Value() != 0 {
+		return d.Value().String()
+	}
+	return "0"
+}
+
+// Set sets the value of the Duration by passing a string
+func (d *Duration) Set(value string) error {
+	val, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	if val < 0 {
+		return fmt.Errorf("invalid duration: %s: must not be negative", value)
+	}
+	*d = Duration(val)
+	return nil
+}
+
+// Type returns the type
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+// Value returns the value as a time.Duration
+func (d *Duration) Value() time.Duration {
+	return time.Duration(*d)
+}
+
+// UnmarshalJSON is the customized unmarshaler for Duration
+func (d *Duration) UnmarshalJSON(s []byte) error {
+	if len(s) <= 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return fmt.Errorf("invalid duration: %q", s)
+	}
+	return d.Set(string(s[1 : len(s)-1]))
+}
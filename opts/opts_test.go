@@ -1,9 +1,11 @@
 package opts // import "github.com/docker/docker/opts"
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateIPAddress(t *testing.T) {
@@ -262,3 +264,32 @@ func TestParseLink(t *testing.T) {
 		t.Fatalf("Expected error 'bad format for links: link:alias:wrong' but got: %v", err)
 	}
 }
+
+func TestDuration(t *testing.T) {
+	var d Duration
+	if d.String() != "0" {
+		t.Fatalf("Expected '0', got %s", d.String())
+	}
+	if err := d.Set("1h30m"); err != nil {
+		t.Fatal(err)
+	}
+	if d.Value() != 90*time.Minute {
+		t.Fatalf("Expected 1h30m, got %v", d.Value())
+	}
+	if err := d.Set("-1h"); err == nil {
+		t.Fatal("Expected an error for a negative duration")
+	}
+	if err := d.Set("1 day"); err == nil {
+		t.Fatal("Expected an error for an invalid duration")
+	}
+
+	if err := json.Unmarshal([]byte(`"10m"`), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Value() != 10*time.Minute {
+		t.Fatalf("Expected 10m, got %v", d.Value())
+	}
+	if err := json.Unmarshal([]byte(`600`), &d); err == nil {
+		t.Fatal("Expected an error for a non-string duration")
+	}
+}
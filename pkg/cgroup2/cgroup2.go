@@ -0,0 +1,47 @@
+// Package cgroup2 provides helpers to inspect the cgroups of processes on
+// hosts using the cgroup v2 unified hierarchy. Resource limits are set by the
+// runtime from the OCI spec.
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UnifiedMountpoint is the mount point of the cgroup v2 unified hierarchy.
+const UnifiedMountpoint = "/sys/fs/cgroup"
+
+// PathForPid returns the path of the cgroup of a process.
+func PathForPid(pid int) (string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// The unified hierarchy is the one with ID 0 and no controller
+		// list: "0::/path".
+		if p := strings.TrimPrefix(s.Text(), "0::"); p != s.Text() {
+			return filepath.Join(UnifiedMountpoint, p), nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("process %d is not in a cgroup v2 hierarchy", pid)
+}
+
+// Controllers returns the controllers available in the cgroup at path dir.
+func Controllers(dir string) ([]string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(b)), nil
+}
@@ -0,0 +1,24 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+var (
+	isUnifiedOnce sync.Once
+	isUnified     bool
+)
+
+// IsUnified returns true if the host uses the cgroup v2 unified hierarchy
+// only, i.e. if a cgroup2 filesystem is mounted at /sys/fs/cgroup.
+func IsUnified() bool {
+	isUnifiedOnce.Do(func() {
+		var st unix.Statfs_t
+		if err := unix.Statfs(UnifiedMountpoint, &st); err == nil {
+			isUnified = st.Type == unix.CGROUP2_SUPER_MAGIC
+		}
+	})
+	return isUnified
+}
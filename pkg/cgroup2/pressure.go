@@ -0,0 +1,86 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Pressure is the pressure stall information (PSI) of a resource, read from
+// the cpu.pressure, memory.pressure and io.pressure interface files.
+type Pressure struct {
+	// Some is the share of time in which at least some tasks were stalled
+	// on the resource.
+	Some *PressureData
+	// Full is the share of time in which all non-idle tasks were stalled
+	// on the resource at the same time.
+	Full *PressureData
+}
+
+// PressureData are the stall averages, as percentages, over the last 10, 60
+// and 300 seconds, and the total stall time in microseconds.
+type PressureData struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
+
+// readPressure reads a pressure file, which has a line per kind of stall in
+// the "some avg10=0.00 avg60=0.00 avg300=0.00 total=0" format.
+func readPressure(filename string) (*Pressure, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p := &Pressure{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		d := &PressureData{}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			var err error
+			switch kv[0] {
+			case "avg10":
+				d.Avg10, err = strconv.ParseFloat(kv[1], 64)
+			case "avg60":
+				d.Avg60, err = strconv.ParseFloat(kv[1], 64)
+			case "avg300":
+				d.Avg300, err = strconv.ParseFloat(kv[1], 64)
+			case "total":
+				d.Total, err = strconv.ParseUint(kv[1], 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in %s: %q", filename, s.Text())
+			}
+		}
+		switch fields[0] {
+		case "some":
+			p.Some = d
+		case "full":
+			p.Full = d
+		}
+	}
+	return p, s.Err()
+}
+
+// isNotSupported returns true if the error is returned for reading a pressure
+// file while PSI is disabled, for example with the psi=0 boot parameter.
+func isNotSupported(err error) bool {
+	if pe, ok := err.(*os.PathError); ok {
+		err = pe.Err
+	}
+	return err == syscall.EOPNOTSUPP
+}
@@ -0,0 +1,209 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Stats are the resource usage statistics of a cgroup.
+type Stats struct {
+	CPU    CPUStats
+	Memory MemoryStats
+	IO     []IOStatEntry
+	Pids   PidsStats
+
+	// The pressure stall information of the resources. They are nil if the
+	// kernel doesn't provide it.
+	CPUPressure    *Pressure
+	MemoryPressure *Pressure
+	IOPressure     *Pressure
+}
+
+// CPUStats are the statistics of the cpu controller, read from cpu.stat.
+// Times are in microseconds.
+type CPUStats struct {
+	UsageUsec     uint64
+	UserUsec      uint64
+	SystemUsec    uint64
+	NrPeriods     uint64
+	NrThrottled   uint64
+	ThrottledUsec uint64
+}
+
+// MemoryStats are the statistics of the memory controller.
+type MemoryStats struct {
+	// Usage is the current memory usage, from memory.current.
+	Usage uint64
+	// Limit is the memory limit, from memory.max. It is 0 if the memory
+	// usage is not limited.
+	Limit uint64
+	// Stats is the content of memory.stat.
+	Stats map[string]uint64
+	// Events is the content of memory.events, which holds the number of
+	// times the cgroup hit its limits, and the number of OOM kills.
+	Events map[string]uint64
+}
+
+// IOStatEntry are the statistics of a device, read from io.stat.
+type IOStatEntry struct {
+	Major  uint64
+	Minor  uint64
+	Rbytes uint64
+	Wbytes uint64
+	Rios   uint64
+	Wios   uint64
+}
+
+// PidsStats are the statistics of the pids controller.
+type PidsStats struct {
+	// Current is the number of processes in the cgroup, from pids.current.
+	Current uint64
+	// Limit is the maximum number of processes, from pids.max. It is 0 if
+	// the number of processes is not limited.
+	Limit uint64
+}
+
+// ReadStats reads the statistics of the cgroup at path dir. The statistics
+// of the controllers which are not enabled for the cgroup are left empty.
+func ReadStats(dir string) (*Stats, error) {
+	s := &Stats{}
+
+	cpu, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	s.CPU = CPUStats{
+		UsageUsec:     cpu["usage_usec"],
+		UserUsec:      cpu["user_usec"],
+		SystemUsec:    cpu["system_usec"],
+		NrPeriods:     cpu["nr_periods"],
+		NrThrottled:   cpu["nr_throttled"],
+		ThrottledUsec: cpu["throttled_usec"],
+	}
+
+	if s.Memory.Stats, err = readKeyValues(filepath.Join(dir, "memory.stat")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if s.Memory.Events, err = readKeyValues(filepath.Join(dir, "memory.events")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if s.Memory.Usage, err = readUint(filepath.Join(dir, "memory.current")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if s.Memory.Limit, err = readUint(filepath.Join(dir, "memory.max")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if s.IO, err = readIOStat(filepath.Join(dir, "io.stat")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if s.Pids.Current, err = readUint(filepath.Join(dir, "pids.current")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if s.Pids.Limit, err = readUint(filepath.Join(dir, "pids.max")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, p := range []struct {
+		file     string
+		pressure **Pressure
+	}{
+		{"cpu.pressure", &s.CPUPressure},
+		{"memory.pressure", &s.MemoryPressure},
+		{"io.pressure", &s.IOPressure},
+	} {
+		if *p.pressure, err = readPressure(filepath.Join(dir, p.file)); err != nil && !os.IsNotExist(err) && !isNotSupported(err) {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// readUint reads a file holding a single value. "max" is read as 0.
+func readUint(filename string) (uint64, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	v := strings.TrimSpace(string(b))
+	if v == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+// readKeyValues reads a flat keyed file, with a "key value" pair per line.
+func readKeyValues(filename string) (map[string]uint64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]uint64)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in %s: %q", filename, s.Text())
+		}
+		values[fields[0]] = v
+	}
+	return values, s.Err()
+}
+
+// readIOStat reads io.stat, which has a line per device in the
+// "major:minor rbytes=1 wbytes=2 rios=3 wios=4 ..." format.
+func readIOStat(filename string) ([]IOStatEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []IOStatEntry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var e IOStatEntry
+		if _, err := fmt.Sscanf(fields[0], "%d:%d", &e.Major, &e.Minor); err != nil {
+			return nil, fmt.Errorf("invalid device in %s: %q", filename, fields[0])
+		}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch kv[0] {
+			case "rbytes":
+				e.Rbytes = v
+			case "wbytes":
+				e.Wbytes = v
+			case "rios":
+				e.Rios = v
+			case "wios":
+				e.Wios = v
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, s.Err()
+}
@@ -0,0 +1,79 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestReadStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup2-stats")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{
+		"cpu.stat":       "usage_usec 1000\nuser_usec 600\nsystem_usec 400\nnr_periods 10\nnr_throttled 2\nthrottled_usec 50\n",
+		"memory.current": "4096\n",
+		"memory.max":     "max\n",
+		"memory.stat":    "anon 1024\nfile 2048\n",
+		"memory.events":  "low 0\nhigh 0\nmax 4\noom 2\noom_kill 1\n",
+		"cpu.pressure":   "some avg10=1.50 avg60=0.75 avg300=0.25 total=12345\n",
+		"io.pressure":    "some avg10=0.00 avg60=0.00 avg300=0.00 total=10\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=5\n",
+		"io.stat":        "8:0 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n",
+		"pids.current":   "3\n",
+		"pids.max":       "100\n",
+	} {
+		assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	stats, err := ReadStats(dir)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(CPUStats{
+		UsageUsec:     1000,
+		UserUsec:      600,
+		SystemUsec:    400,
+		NrPeriods:     10,
+		NrThrottled:   2,
+		ThrottledUsec: 50,
+	}, stats.CPU))
+	assert.Check(t, is.DeepEqual(MemoryStats{
+		Usage:  4096,
+		Stats:  map[string]uint64{"anon": 1024, "file": 2048},
+		Events: map[string]uint64{"low": 0, "high": 0, "max": 4, "oom": 2, "oom_kill": 1},
+	}, stats.Memory))
+	assert.Check(t, is.DeepEqual([]IOStatEntry{{Major: 8, Minor: 0, Rbytes: 100, Wbytes: 200, Rios: 1, Wios: 2}}, stats.IO))
+	assert.Check(t, is.DeepEqual(PidsStats{Current: 3, Limit: 100}, stats.Pids))
+	assert.Check(t, is.DeepEqual(&Pressure{
+		Some: &PressureData{Avg10: 1.5, Avg60: 0.75, Avg300: 0.25, Total: 12345},
+	}, stats.CPUPressure))
+	assert.Check(t, is.Nil(stats.MemoryPressure))
+	assert.Check(t, is.DeepEqual(&Pressure{
+		Some: &PressureData{Total: 10},
+		Full: &PressureData{Total: 5},
+	}, stats.IOPressure))
+}
+
+func TestReadPressureInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup2-stats")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "cpu.pressure")
+	assert.NilError(t, ioutil.WriteFile(filename, []byte("some avg10=abc avg60=0.00 avg300=0.00 total=0\n"), 0644))
+	_, err = readPressure(filename)
+	assert.Check(t, is.ErrorContains(err, "invalid value"))
+}
+
+func TestReadStatsMissingControllers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup2-stats")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	stats, err := ReadStats(dir)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(&Stats{}, stats))
+}
@@ -0,0 +1,16 @@
+package sysinfo // import "github.com/docker/docker/pkg/sysinfo"
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// checkCgroup2 fills the cgroup information on hosts using the cgroup v2
+// unified hierarchy. The runtime shipped with the daemon cannot apply the
+// resources of the OCI spec on cgroup v2 yet, so no resource limit is
+// reported as supported, whichever controllers are available, and the device
+// access of containers cannot be restricted.
+func checkCgroup2(sysInfo *SysInfo, quiet bool) {
+	if !quiet {
+		logrus.Warn("Memory, cpu, io, cpuset and pids limits are not supported with cgroup v2 by the runtime")
+	}
+}
@@ -27,6 +27,12 @@ type SysInfo struct {
 
 	// Whether the cgroup has the mountpoint of "devices" or not
 	CgroupDevicesEnabled bool
+
+	// Whether the host uses the cgroup v2 unified hierarchy or not
+	CgroupUnified bool
+
+	// Whether the kernel supports cgroup namespaces or not
+	CgroupNamespaces bool
 }
 
 type cgroupMemInfo struct {
@@ -3,6 +3,8 @@ package restartmanager // import "github.com/docker/docker/restartmanager"
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -10,9 +12,10 @@ import (
 )
 
 const (
-	backoffMultiplier = 2
-	defaultTimeout    = 100 * time.Millisecond
-	maxRestartTimeout = 1 * time.Minute
+	backoffMultiplier  = 2
+	defaultTimeout     = 100 * time.Millisecond
+	maxRestartTimeout  = 1 * time.Minute
+	defaultResetWindow = 10 * time.Second
 )
 
 // ErrRestartCanceled is returned when the restart manager has been
@@ -23,6 +26,16 @@ var ErrRestartCanceled = errors.New("restart canceled")
 type RestartManager interface {
 	Cancel() error
 	ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error)
+	// CrashLooping reports whether the last restart was delayed by the
+	// maximum backoff, meaning the container keeps exiting shortly after
+	// being started.
+	CrashLooping() bool
+	// RestartOnNextExit makes the next exit of the container restart it
+	// regardless of the policy and exit code, unless it was manually stopped.
+	RestartOnNextExit()
+	// ClearRestartOnNextExit undoes RestartOnNextExit if the container has
+	// not exited since.
+	ClearRestartOnNextExit()
 }
 
 type restartManager struct {
@@ -31,14 +44,80 @@ type restartManager struct {
 	policy       container.RestartPolicy
 	restartCount int
 	timeout      time.Duration
+	crashLooping bool
+	forceRestart bool
 	active       bool
 	cancel       chan struct{}
 	canceled     bool
+	rand         *rand.Rand // source of the jitter, used with the lock held
 }
 
 // New returns a new restartManager based on a policy.
 func New(policy container.RestartPolicy, restartCount int) RestartManager {
-	return &restartManager{policy: policy, restartCount: restartCount, cancel: make(chan struct{})}
+	return &restartManager{
+		policy:       policy,
+		restartCount: restartCount,
+		cancel:       make(chan struct{}),
+		rand:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// ValidateBackoff checks that the passed in backoff settings can be used to
+// restart a container.
+func ValidateBackoff(b *container.RestartBackoff) error {
+	if b == nil {
+		return nil
+	}
+	if b.InitialDelay < 0 || b.MaxDelay < 0 || b.ResetWindow < 0 {
+		return errors.New("restart backoff delays cannot be negative")
+	}
+	if b.InitialDelay != 0 && b.MaxDelay != 0 && b.InitialDelay > b.MaxDelay {
+		return fmt.Errorf("restart backoff initial delay (%s) cannot be greater than the maximum delay (%s)", b.InitialDelay, b.MaxDelay)
+	}
+	if b.Multiplier != 0 && b.Multiplier < 1 {
+		return fmt.Errorf("restart backoff multiplier must be 1 or greater, got %g", b.Multiplier)
+	}
+	if b.Jitter < 0 || b.Jitter > 1 {
+		return fmt.Errorf("restart backoff jitter must be between 0 and 1, got %g", b.Jitter)
+	}
+	return nil
+}
+
+// backoff returns the backoff settings of the policy with the defaults
+// applied for unset values.
+func backoff(policy container.RestartPolicy) container.RestartBackoff {
+	b := container.RestartBackoff{
+		InitialDelay: defaultTimeout,
+		MaxDelay:     maxRestartTimeout,
+		Multiplier:   backoffMultiplier,
+		ResetWindow:  defaultResetWindow,
+	}
+	if policy.Backoff == nil {
+		return b
+	}
+	if policy.Backoff.InitialDelay != 0 {
+		b.InitialDelay = policy.Backoff.InitialDelay
+	}
+	if policy.Backoff.MaxDelay != 0 {
+		b.MaxDelay = policy.Backoff.MaxDelay
+	}
+	if b.InitialDelay > b.MaxDelay {
+		b.MaxDelay = b.InitialDelay
+	}
+	if policy.Backoff.Multiplier != 0 {
+		b.Multiplier = policy.Backoff.Multiplier
+	}
+	if policy.Backoff.ResetWindow != 0 {
+		b.ResetWindow = policy.Backoff.ResetWindow
+	}
+	b.Jitter = policy.Backoff.Jitter
+	return b
+}
+
+// ResetWindow returns how long a container must run before the restart
+// delay of the policy goes back to its initial value.
+func ResetWindow(policy container.RestartPolicy) time.Duration {
+	return backoff(policy).ResetWindow
 }
 
 func (rm *restartManager) SetPolicy(policy container.RestartPolicy) {
@@ -47,11 +126,32 @@ func (rm *restartManager) SetPolicy(policy container.RestartPolicy) {
 	rm.Unlock()
 }
 
+func (rm *restartManager) CrashLooping() bool {
+	rm.Lock()
+	defer rm.Unlock()
+	return rm.crashLooping
+}
+
+func (rm *restartManager) RestartOnNextExit() {
+	rm.Lock()
+	rm.forceRestart = true
+	rm.Unlock()
+}
+
+func (rm *restartManager) ClearRestartOnNextExit() {
+	rm.Lock()
+	rm.forceRestart = false
+	rm.Unlock()
+}
+
 func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error) {
-	if rm.policy.IsNone() {
+	rm.Lock()
+	forceRestart := rm.forceRestart
+	rm.forceRestart = false
+	if rm.policy.IsNone() && !forceRestart {
+		rm.Unlock()
 		return false, nil, nil
 	}
-	rm.Lock()
 	unlockOnExit := true
 	defer func() {
 		if unlockOnExit {
@@ -66,23 +166,33 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if rm.active {
 		return false, nil, fmt.Errorf("invalid call on an active restart manager")
 	}
-	// if the container ran for more than 10s, regardless of status and policy reset the
-	// the timeout back to the default.
-	if executionDuration.Seconds() >= 10 {
+
+	b := backoff(rm.policy)
+	// if the container ran for longer than the reset window, regardless of
+	// status and policy reset the timeout back to the initial delay.
+	if executionDuration >= b.ResetWindow {
 		rm.timeout = 0
 	}
+	previous := rm.timeout
 	switch {
 	case rm.timeout == 0:
-		rm.timeout = defaultTimeout
-	case rm.timeout < maxRestartTimeout:
-		rm.timeout *= backoffMultiplier
+		rm.timeout = b.InitialDelay
+	case rm.timeout < b.MaxDelay:
+		// clamp before converting back, the product can overflow a time.Duration
+		if next := float64(rm.timeout) * b.Multiplier; next < float64(b.MaxDelay) {
+			rm.timeout = time.Duration(next)
+		} else {
+			rm.timeout = b.MaxDelay
+		}
 	}
-	if rm.timeout > maxRestartTimeout {
-		rm.timeout = maxRestartTimeout
+	if rm.timeout > b.MaxDelay {
+		rm.timeout = b.MaxDelay
 	}
 
 	var restart bool
 	switch {
+	case forceRestart && !hasBeenManuallyStopped:
+		restart = true
 	case rm.policy.IsAlways():
 		restart = true
 	case rm.policy.IsUnlessStopped() && !hasBeenManuallyStopped:
@@ -96,10 +206,13 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 
 	if !restart {
 		rm.active = false
+		rm.crashLooping = false
 		return false, nil, nil
 	}
 
 	rm.restartCount++
+	rm.crashLooping = previous != 0 && rm.timeout >= b.MaxDelay
+	delay := withJitter(rm.rand, rm.timeout, b.Jitter)
 
 	unlockOnExit = false
 	rm.active = true
@@ -111,7 +224,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 		case <-rm.cancel:
 			ch <- ErrRestartCanceled
 			close(ch)
-		case <-time.After(rm.timeout):
+		case <-time.After(delay):
 			rm.Lock()
 			close(ch)
 			rm.active = false
@@ -122,6 +235,18 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	return true, ch, nil
 }
 
+// withJitter randomly adds or removes up to the jitter fraction of d.
+func withJitter(r *rand.Rand, d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	delay := float64(d) * (1 + jitter*(2*r.Float64()-1))
+	if delay >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
 func (rm *restartManager) Cancel() error {
 	rm.Do(func() {
 		rm.Lock()
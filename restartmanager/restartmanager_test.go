@@ -1,6 +1,8 @@
 package restartmanager // import "github.com/docker/docker/restartmanager"
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -34,3 +36,158 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerCustomBackoff(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name: "always",
+		Backoff: &container.RestartBackoff{
+			InitialDelay: time.Millisecond,
+			MaxDelay:     4 * time.Millisecond,
+			Multiplier:   2,
+			ResetWindow:  time.Second,
+		},
+	}
+	rm := New(policy, 0).(*restartManager)
+
+	for _, expected := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond} {
+		should, wait, err := rm.ShouldRestart(1, false, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !should {
+			t.Fatal("container should be restarted")
+		}
+		if rm.timeout != expected {
+			t.Fatalf("expected timeout of %s, got %s", expected, rm.timeout)
+		}
+		if err := <-wait; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !rm.CrashLooping() {
+		t.Fatal("restart manager should report a crash loop once the maximum delay is reached")
+	}
+
+	// running for longer than the reset window ends the crash loop
+	if _, wait, err := rm.ShouldRestart(1, false, time.Second); err != nil {
+		t.Fatal(err)
+	} else {
+		<-wait
+	}
+	if rm.timeout != time.Millisecond {
+		t.Fatalf("expected timeout to be reset to %s, got %s", time.Millisecond, rm.timeout)
+	}
+	if rm.CrashLooping() {
+		t.Fatal("restart manager should not report a crash loop after the reset window")
+	}
+}
+
+func TestRestartManagerBackoffOverflow(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name: "on-failure",
+		Backoff: &container.RestartBackoff{
+			InitialDelay: time.Second,
+			MaxDelay:     time.Hour,
+			Multiplier:   1e300,
+			ResetWindow:  time.Minute,
+		},
+	}
+	rm := New(policy, 0).(*restartManager)
+	rm.timeout = time.Second
+	// a successful exit computes the next delay without starting a restart
+	if _, _, err := rm.ShouldRestart(0, false, 0); err != nil {
+		t.Fatal(err)
+	}
+	if rm.timeout != time.Hour {
+		t.Fatalf("expected timeout to be clamped to %s, got %s", time.Hour, rm.timeout)
+	}
+}
+
+func TestRestartManagerRestartOnNextExit(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "no"}, 0).(*restartManager)
+	rm.RestartOnNextExit()
+	should, wait, err := rm.ShouldRestart(0, false, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !should {
+		t.Fatal("container should be restarted")
+	}
+	if err := <-wait; err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the next exit is affected.
+	should, _, err = rm.ShouldRestart(0, false, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if should {
+		t.Fatal("container should not be restarted")
+	}
+
+	// A manual stop always wins.
+	rm.RestartOnNextExit()
+	should, _, err = rm.ShouldRestart(0, true, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if should {
+		t.Fatal("manually stopped container should not be restarted")
+	}
+
+	// A cleared request has no effect.
+	rm.RestartOnNextExit()
+	rm.ClearRestartOnNextExit()
+	should, _, err = rm.ShouldRestart(0, false, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if should {
+		t.Fatal("container should not be restarted after the request was cleared")
+	}
+}
+
+func TestRestartManagerJitter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		d := withJitter(r, time.Second, 0.5)
+		if d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("delay %s out of jitter bounds", d)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		d := withJitter(r, time.Duration(math.MaxInt64), 1)
+		if d < 0 {
+			t.Fatalf("delay %s overflowed", d)
+		}
+	}
+	if d := withJitter(r, time.Second, 0); d != time.Second {
+		t.Fatalf("expected no jitter, got %s", d)
+	}
+}
+
+func TestValidateBackoff(t *testing.T) {
+	valid := []*container.RestartBackoff{
+		nil,
+		{},
+		{InitialDelay: time.Second, MaxDelay: time.Minute, Multiplier: 1.5, Jitter: 0.2, ResetWindow: time.Minute},
+	}
+	for _, b := range valid {
+		if err := ValidateBackoff(b); err != nil {
+			t.Fatalf("unexpected error for %+v: %v", b, err)
+		}
+	}
+
+	invalid := []*container.RestartBackoff{
+		{InitialDelay: -1},
+		{InitialDelay: time.Minute, MaxDelay: time.Second},
+		{Multiplier: 0.5},
+		{Jitter: 1.5},
+	}
+	for _, b := range invalid {
+		if err := ValidateBackoff(b); err == nil {
+			t.Fatalf("expected error for %+v", b)
+		}
+	}
+}
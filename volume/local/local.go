@@ -58,6 +58,7 @@ func New(scope string, rootIDs idtools.IDPair) (*Root, error) {
 		path:    rootDirectory,
 		volumes: make(map[string]*localVolume),
 		rootIDs: rootIDs,
+		quota:   newQuotaControl(rootDirectory),
 	}
 
 	dirs, err := ioutil.ReadDir(rootDirectory)
@@ -75,6 +76,7 @@ func New(scope string, rootIDs idtools.IDPair) (*Root, error) {
 			driverName: r.Name(),
 			name:       name,
 			path:       r.DataPath(name),
+			quota:      r.quota,
 		}
 		r.volumes[name] = v
 		optsFilePath := filepath.Join(rootDirectory, name, "opts.json")
@@ -106,6 +108,7 @@ type Root struct {
 	path    string
 	volumes map[string]*localVolume
 	rootIDs idtools.IDPair
+	quota   *quotaControl
 }
 
 // List lists all the volumes
@@ -146,7 +149,17 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	}
 
 	path := r.DataPath(name)
-	if err := idtools.MkdirAllAndChown(path, 0755, r.rootIDs); err != nil {
+	v = &localVolume{
+		driverName: r.Name(),
+		name:       name,
+		path:       path,
+		quota:      r.quota,
+	}
+	if err := setOpts(v, opts); err != nil {
+		return nil, err
+	}
+
+	if err := idtools.MkdirAllAndChown(filepath.Dir(path), 0755, r.rootIDs); err != nil {
 		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", path)
 	}
 
@@ -157,16 +170,16 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		}
 	}()
 
-	v = &localVolume{
-		driverName: r.Name(),
-		name:       name,
-		path:       path,
+	// The quota is set before the data directory is created, for it to be
+	// inherited by the data directory.
+	if err = v.setupQuota(filepath.Dir(path)); err != nil {
+		return nil, errdefs.System(errors.Wrap(err, "error while setting volume quota"))
+	}
+	if err = idtools.MkdirAllAndChown(path, 0755, r.rootIDs); err != nil {
+		return nil, errors.Wrapf(errdefs.System(err), "error while creating volume path '%s'", path)
 	}
 
 	if len(opts) != 0 {
-		if err = setOpts(v, opts); err != nil {
-			return nil, err
-		}
 		var b []byte
 		b, err = json.Marshal(v.opts)
 		if err != nil {
@@ -280,6 +293,8 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// quota sets and reports the size quota of the volume
+	quota *quotaControl
 }
 
 // Name returns the name of the given Volume.
@@ -307,7 +322,7 @@ func (v *localVolume) CachedPath() string {
 func (v *localVolume) Mount(id string) (string, error) {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		if !v.active.mounted {
 			if err := v.mount(); err != nil {
 				return "", errdefs.System(err)
@@ -329,7 +344,7 @@ func (v *localVolume) Unmount(id string) error {
 	// Essentially docker doesn't care if this fails, it will send an error, but
 	// ultimately there's nothing that can be done. If we don't decrement the count
 	// this volume can never be removed until a daemon restart occurs.
-	if v.opts != nil {
+	if v.needsMount() {
 		v.active.count--
 	}
 
@@ -341,7 +356,7 @@ func (v *localVolume) Unmount(id string) error {
 }
 
 func (v *localVolume) unmount() error {
-	if v.opts != nil {
+	if v.needsMount() {
 		if err := mount.Unmount(v.path); err != nil {
 			if mounted, mErr := mount.Mounted(v.path); mounted || mErr != nil {
 				return errdefs.System(errors.Wrapf(err, "error while unmounting volume path '%s'", v.path))
@@ -361,8 +376,17 @@ func validateOpts(opts map[string]string) error {
 	return nil
 }
 
+// Status returns the size quota of the volume and its usage, in bytes, if
+// the volume was created with a size.
 func (v *localVolume) Status() map[string]interface{} {
-	return nil
+	size, usage, err := v.quota.getQuota(filepath.Dir(v.path))
+	if err != nil || size == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"Size":  size,
+		"Usage": usage,
+	}
 }
 
 // getAddress finds out address/hostname from options
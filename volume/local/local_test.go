@@ -276,6 +276,63 @@ func TestCreateWithOpts(t *testing.T) {
 	}
 }
 
+func TestCreateWithSize(t *testing.T) {
+	skip.If(t, runtime.GOOS == "windows")
+	rootDir, err := ioutil.TempDir("", "local-volume-test-size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []map[string]string{
+		{"size": "invalid"},
+		{"size": "0"},
+		{"size": "10m", "type": "tmpfs", "device": "tmpfs"},
+	} {
+		if _, err := r.Create("test", opts); err == nil {
+			t.Fatalf("expected %v to cause error", opts)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, volumesPathName, "test")); !os.IsNotExist(err) {
+		t.Fatalf("expected the volume directory not to be created, got: %v", err)
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "10m"})
+	if !r.quota.supported() {
+		if err == nil {
+			t.Fatal("expected size to cause error without quota support")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vol.(*localVolume)
+	if v.needsMount() {
+		t.Fatal("expected volume with a size not to be mounted")
+	}
+	status := v.Status()
+	if status["Size"] != uint64(10*1024*1024) {
+		t.Fatalf("expected size quota of 10m, got: %v", status)
+	}
+
+	r, err = New(rootDir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v.opts, r.volumes["test"].opts) {
+		t.Fatal("missing volume options on restart")
+	}
+	if !reflect.DeepEqual(status, r.volumes["test"].Status()) {
+		t.Fatal("missing volume quota on restart")
+	}
+}
+
 func TestRelaodNoOpts(t *testing.T) {
 	rootDir, err := ioutil.TempDir("", "volume-test-reload-no-opts")
 	if err != nil {
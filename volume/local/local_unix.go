@@ -14,9 +14,9 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/pkg/errors"
-
 	"github.com/docker/docker/pkg/mount"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
 )
 
 var (
@@ -26,6 +26,7 @@ var (
 		"type":   true, // specify the filesystem type for mount, e.g. nfs
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
+		"size":   true, // size quota of the volume
 	}
 )
 
@@ -33,6 +34,7 @@ type optsConfig struct {
 	MountType   string
 	MountOpts   string
 	MountDevice string
+	Size        uint64 `json:",omitempty"`
 }
 
 func (o *optsConfig) String() string {
@@ -63,14 +65,46 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		return err
 	}
 
-	v.opts = &optsConfig{
+	cfg := &optsConfig{
 		MountType:   opts["type"],
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+	if val, ok := opts["size"]; ok {
+		size, err := units.RAMInBytes(val)
+		if err != nil {
+			return validationError(fmt.Sprintf("invalid size: %q", val))
+		}
+		if size <= 0 {
+			return validationError(fmt.Sprintf("invalid size: %q: must be positive", val))
+		}
+		if cfg.MountType != "" || cfg.MountOpts != "" || cfg.MountDevice != "" {
+			return validationError("size cannot be used with the type, o and device options")
+		}
+		if !v.quota.supported() {
+			return validationError(errSizeNotSupported)
+		}
+		cfg.Size = uint64(size)
+	}
+	v.opts = cfg
 	return nil
 }
 
+// needsMount returns whether the volume is mounted according to its mount
+// options.
+func (v *localVolume) needsMount() bool {
+	return v.opts != nil && (v.opts.MountType != "" || v.opts.MountOpts != "" || v.opts.MountDevice != "")
+}
+
+// setupQuota sets the size quota of the volume on dir, if the volume was
+// created with a size.
+func (v *localVolume) setupQuota(dir string) error {
+	if v.opts == nil || v.opts.Size == 0 {
+		return nil
+	}
+	return v.quota.setQuota(dir, v.opts.Size)
+}
+
 func (v *localVolume) mount() error {
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
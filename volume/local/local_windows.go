@@ -32,6 +32,14 @@ func setOpts(v *localVolume, opts map[string]string) error {
 	return nil
 }
 
+func (v *localVolume) needsMount() bool {
+	return v.opts != nil
+}
+
+func (v *localVolume) setupQuota(dir string) error {
+	return nil
+}
+
 func (v *localVolume) mount() error {
 	return nil
 }
@@ -0,0 +1,60 @@
+// +build linux,cgo
+
+package local // import "github.com/docker/docker/volume/local"
+
+import (
+	"github.com/docker/docker/daemon/graphdriver/quota"
+	"github.com/sirupsen/logrus"
+)
+
+// errSizeNotSupported is the message of the error returned when a volume is
+// created with a size on a volumes root without project quotas.
+const errSizeNotSupported = "size is supported only for volumes on xfs with the 'pquota' mount option"
+
+// quotaControl sets and reports the project quotas of the volumes. It is
+// nil when the volumes root does not support project quotas. The quota
+// control is shared with the storage driver when it uses project quotas on
+// the same filesystem, for the project ids not to collide.
+type quotaControl struct {
+	ctl *quota.Control
+}
+
+func newQuotaControl(root string) *quotaControl {
+	ctl, err := quota.NewControl(root)
+	if err != nil {
+		if err != quota.ErrQuotaNotSupported {
+			logrus.Warnf("Unable to setup quota for local volumes: %v", err)
+		}
+		return nil
+	}
+	return &quotaControl{ctl: ctl}
+}
+
+func (q *quotaControl) supported() bool {
+	return q != nil
+}
+
+// setQuota limits the size of dir, and of the files and directories created
+// in it afterwards, to size bytes.
+func (q *quotaControl) setQuota(dir string, size uint64) error {
+	if q == nil {
+		return quota.ErrQuotaNotSupported
+	}
+	return q.ctl.SetQuota(dir, quota.Quota{Size: size})
+}
+
+// getQuota returns the size limit and the usage of dir.
+func (q *quotaControl) getQuota(dir string) (size, usage uint64, err error) {
+	if q == nil {
+		return 0, 0, quota.ErrQuotaNotSupported
+	}
+	var qt quota.Quota
+	if err := q.ctl.GetQuota(dir, &qt); err != nil {
+		return 0, 0, err
+	}
+	usage, err = q.ctl.GetUsage(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	return qt.Size, usage, nil
+}
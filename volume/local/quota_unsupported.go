@@ -0,0 +1,28 @@
+// +build !linux !cgo
+
+package local // import "github.com/docker/docker/volume/local"
+
+import "github.com/docker/docker/daemon/graphdriver/quota"
+
+// errSizeNotSupported is the message of the error returned when a volume is
+// created with a size: project quotas require a Linux daemon built with cgo.
+const errSizeNotSupported = "size is not supported on this platform, or by a daemon built without cgo"
+
+type quotaControl struct {
+}
+
+func newQuotaControl(root string) *quotaControl {
+	return nil
+}
+
+func (q *quotaControl) supported() bool {
+	return false
+}
+
+func (q *quotaControl) setQuota(dir string, size uint64) error {
+	return quota.ErrQuotaNotSupported
+}
+
+func (q *quotaControl) getQuota(dir string) (size, usage uint64, err error) {
+	return 0, 0, quota.ErrQuotaNotSupported
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -24,6 +25,10 @@ type calcSize bool
 
 func (calcSize) isConvertOpt() {}
 
+type resolveReferences bool
+
+func (resolveReferences) isConvertOpt() {}
+
 type pathCacher interface {
 	CachedPath() string
 }
@@ -33,6 +38,7 @@ func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volu
 		out        = make([]*types.Volume, 0, len(volumes))
 		getSize    bool
 		cachedPath bool
+		getRefs    bool
 	)
 
 	for _, o := range opts {
@@ -41,6 +47,8 @@ func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volu
 			getSize = bool(t)
 		case useCachedPath:
 			cachedPath = bool(t)
+		case resolveReferences:
+			getRefs = bool(t)
 		}
 	}
 	for _, v := range volumes {
@@ -72,6 +80,10 @@ func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volu
 			apiV.UsageData = &types.VolumeUsageData{Size: sz, RefCount: int64(s.vs.CountReferences(v))}
 		}
 
+		if getRefs {
+			apiV.References = s.references(v)
+		}
+
 		out = append(out, &apiV)
 	}
 	return out
@@ -95,6 +107,22 @@ func volumeToAPIType(v volume.Volume) types.Volume {
 	return tv
 }
 
+// isReferencedBy returns whether one of the references is a mount by one of
+// the containers, given by name, ID or ID prefix.
+func isReferencedBy(refs []types.VolumeReference, containers []string) bool {
+	for _, ref := range refs {
+		for _, c := range containers {
+			if c == "" {
+				continue
+			}
+			if ref.ContainerName == strings.TrimPrefix(c, "/") || strings.HasPrefix(ref.ContainerID, c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func filtersToBy(filter filters.Args, acceptedFilters map[string]bool) (By, error) {
 	if err := filter.Validate(acceptedFilters); err != nil {
 		return nil, err
@@ -0,0 +1,10 @@
+package service // import "github.com/docker/docker/volume/service"
+
+import "github.com/docker/docker/daemon/graphdriver/copy"
+
+// copyVolumeData copies the content of src to dst. The files are cloned with
+// reflinks when the backing filesystem supports it, such as btrfs or xfs, and
+// copied with copy_file_range, or read and written, otherwise.
+func copyVolumeData(src, dst string) error {
+	return copy.DirCopy(src, dst, copy.Content, false)
+}
@@ -0,0 +1,10 @@
+// +build !linux
+
+package service // import "github.com/docker/docker/volume/service"
+
+import "github.com/docker/docker/pkg/chrootarchive"
+
+// copyVolumeData copies the content of src to dst.
+func copyVolumeData(src, dst string) error {
+	return chrootarchive.NewArchiver(nil).CopyWithTar(src, dst)
+}
@@ -12,6 +12,8 @@ const (
 	errNoSuchVolume notFoundError = "no such volume"
 	// errNameConflict is a typed error returned on create when a volume exists with the given name, but for a different driver
 	errNameConflict conflictError = "volume name must be unique"
+	// errVolumeExists is a typed error returned on an exclusive create when a volume exists with the given name
+	errVolumeExists conflictError = "volume already exists"
 )
 
 type conflictError string
@@ -9,6 +9,7 @@ type CreateConfig struct {
 	Options   map[string]string
 	Labels    map[string]string
 	Reference string
+	Exclusive bool
 }
 
 // WithCreateLabels creates a CreateOption which sets the labels to the
@@ -36,12 +37,22 @@ func WithCreateReference(ref string) CreateOption {
 	}
 }
 
+// WithCreateExclusive creates a CreateOption which makes the creation fail
+// if a volume with the same name already exists, instead of returning the
+// existing volume.
+func WithCreateExclusive() CreateOption {
+	return func(cfg *CreateConfig) {
+		cfg.Exclusive = true
+	}
+}
+
 // GetConfig is used with `GetOption` to set options for the volumes service's
 // `Get` implementation.
 type GetConfig struct {
-	Driver        string
-	Reference     string
-	ResolveStatus bool
+	Driver            string
+	Reference         string
+	ResolveStatus     bool
+	ResolveReferences bool
 }
 
 // GetOption is passed to the service `Get` add extra details on the get request
@@ -71,6 +82,12 @@ func WithGetResolveStatus(cfg *GetConfig) {
 	cfg.ResolveStatus = true
 }
 
+// WithGetResolveReferences indicates to `Get` to also fetch the mounts of the
+// volume by the containers referencing it.
+func WithGetResolveReferences(cfg *GetConfig) {
+	cfg.ResolveReferences = true
+}
+
 // RemoveConfig is used by `RemoveOption` to store config options for remove
 type RemoveConfig struct {
 	PurgeOnError bool
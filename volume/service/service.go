@@ -2,13 +2,17 @@ package service // import "github.com/docker/docker/volume/service"
 
 import (
 	"context"
+	"io"
 	"sync/atomic"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/plugingetter"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/volume"
@@ -26,18 +30,27 @@ type volumeEventLogger interface {
 	LogVolumeEvent(volumeID, action string, attributes map[string]string)
 }
 
+type volumeReferenceResolver interface {
+	VolumeReferences(volumeName string, refs []string) []types.VolumeReference
+}
+
 // VolumesService manages access to volumes
 type VolumesService struct {
 	vs           *VolumeStore
 	ds           ds
 	pruneRunning int32
 	eventLogger  volumeEventLogger
+	refResolver  volumeReferenceResolver
+	idMappings   *idtools.IDMappings
 }
 
-// NewVolumeService creates a new volume service
-func NewVolumeService(root string, pg plugingetter.PluginGetter, rootIDs idtools.IDPair, logger volumeEventLogger) (*VolumesService, error) {
+// NewVolumeService creates a new volume service. The references to the
+// volumes are resolved to the mounts of the containers using them by
+// resolver. The files of imported archives are owned by the users and
+// groups mapped by idMappings.
+func NewVolumeService(root string, pg plugingetter.PluginGetter, idMappings *idtools.IDMappings, logger volumeEventLogger, resolver volumeReferenceResolver) (*VolumesService, error) {
 	ds := drivers.NewStore(pg)
-	if err := setupDefaultDriver(ds, root, rootIDs); err != nil {
+	if err := setupDefaultDriver(ds, root, idMappings.RootPair()); err != nil {
 		return nil, err
 	}
 
@@ -45,7 +58,7 @@ func NewVolumeService(root string, pg plugingetter.PluginGetter, rootIDs idtools
 	if err != nil {
 		return nil, err
 	}
-	return &VolumesService{vs: vs, ds: ds, eventLogger: logger}, nil
+	return &VolumesService{vs: vs, ds: ds, eventLogger: logger, refResolver: resolver, idMappings: idMappings}, nil
 }
 
 // GetDriverList gets the list of registered volume drivers
@@ -84,9 +97,25 @@ func (s *VolumesService) Get(ctx context.Context, name string, getOpts ...opts.G
 	if cfg.ResolveStatus {
 		vol.Status = v.Status()
 	}
+	if cfg.ResolveReferences {
+		vol.References = s.references(v)
+	}
 	return &vol, nil
 }
 
+// references returns the mounts of the volume by the containers referencing
+// it. The other references, such as exports of the volume, are skipped.
+func (s *VolumesService) references(v volume.Volume) []types.VolumeReference {
+	if s.refResolver == nil {
+		return nil
+	}
+	refs := s.vs.References(v)
+	if len(refs) == 0 {
+		return nil
+	}
+	return s.refResolver.VolumeReferences(v.Name(), refs)
+}
+
 // Mount mounts the volume
 func (s *VolumesService) Mount(ctx context.Context, vol *types.Volume, ref string) (string, error) {
 	v, err := s.vs.Get(ctx, vol.Name, opts.WithGetDriver(vol.Driver))
@@ -117,6 +146,154 @@ func (s *VolumesService) Release(ctx context.Context, name string, ref string) e
 	return s.vs.Release(ctx, name, ref)
 }
 
+// Export returns a tar archive of the content of the volume, compressed with
+// the given compression. The files are owned by the users and groups of the
+// containers in the archive. The volume is referenced and mounted until the
+// archive is closed, so that it cannot be removed during the transfer.
+func (s *VolumesService) Export(ctx context.Context, name string, compression archive.Compression) (io.ReadCloser, error) {
+	v, ref, path, err := s.mountForTransfer(ctx, name, "export")
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := archive.TarWithOptions(path, &archive.TarOptions{
+		Compression: compression,
+		UIDMaps:     s.idMappings.UIDs(),
+		GIDMaps:     s.idMappings.GIDs(),
+	})
+	if err != nil {
+		s.releaseTransfer(ctx, v, ref)
+		return nil, err
+	}
+
+	s.eventLogger.LogVolumeEvent(v.Name(), "export", map[string]string{"driver": v.DriverName()})
+	return ioutils.NewReadCloserWrapper(rdr, func() error {
+		err := rdr.Close()
+		s.releaseTransfer(context.Background(), v, ref)
+		return err
+	}), nil
+}
+
+// Import extracts the tar archive read from r, which may be compressed, to
+// the volume. The users and groups of the files of the archive are mapped to
+// the ones of the host. The volume must exist, and is referenced and mounted during
+// the transfer.
+func (s *VolumesService) Import(ctx context.Context, name string, r io.Reader) error {
+	v, ref, path, err := s.mountForTransfer(ctx, name, "import")
+	if err != nil {
+		return err
+	}
+	defer s.releaseTransfer(ctx, v, ref)
+
+	options := &archive.TarOptions{
+		UIDMaps: s.idMappings.UIDs(),
+		GIDMaps: s.idMappings.GIDs(),
+	}
+	if err := chrootarchive.Untar(r, path, options); err != nil {
+		return errors.Wrapf(err, "error importing volume %s", v.Name())
+	}
+
+	s.eventLogger.LogVolumeEvent(v.Name(), "import", map[string]string{"driver": v.DriverName()})
+	return nil
+}
+
+// Clone creates a volume with the same driver and options as the volume
+// name, and copies the content of the volume to it. The new volume has the
+// labels of the volume, unless labels is not nil. Both volumes are referenced
+// and mounted during the copy. Local volumes mounted from a device or with
+// mount options cannot be cloned: the clone would mount the same device.
+func (s *VolumesService) Clone(ctx context.Context, name, newName string, labels map[string]string) (*types.Volume, error) {
+	v, err := s.vs.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if dv, ok := v.(volume.DetailedVolume); ok && v.DriverName() == volume.DefaultDriverName {
+		for _, o := range []string{"type", "device", "o"} {
+			if dv.Options()[o] != "" {
+				return nil, errdefs.InvalidParameter(errors.Errorf("volume %s cannot be cloned: it is mounted with the %q option", v.Name(), o))
+			}
+		}
+	}
+
+	src, srcRef, srcPath, err := s.mountForTransfer(ctx, name, "clone")
+	if err != nil {
+		return nil, err
+	}
+	defer s.releaseTransfer(ctx, src, srcRef)
+
+	if newName == "" {
+		newName = stringid.GenerateNonCryptoID()
+	}
+
+	var driverOpts map[string]string
+	if dv, ok := src.(volume.DetailedVolume); ok {
+		driverOpts = dv.Options()
+		if labels == nil {
+			labels = dv.Labels()
+		}
+	}
+
+	dstRef := "clone-" + stringid.GenerateNonCryptoID()
+	// The creation is exclusive, so that the volume removed if the copy
+	// fails is always the one created here.
+	dst, err := s.vs.Create(ctx, newName, src.DriverName(), opts.WithCreateOptions(driverOpts), opts.WithCreateLabels(labels), opts.WithCreateReference(dstRef), opts.WithCreateExclusive())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.copyVolume(ctx, srcPath, dst, dstRef); err != nil {
+		if err := s.vs.Remove(ctx, dst); err != nil {
+			logrus.WithError(err).WithField("volume", dst.Name()).Warn("failed to remove volume after failed clone")
+		}
+		return nil, errors.Wrapf(err, "error cloning volume %s", src.Name())
+	}
+
+	s.eventLogger.LogVolumeEvent(dst.Name(), "create", map[string]string{"driver": dst.DriverName()})
+	apiV := volumeToAPIType(dst)
+	return &apiV, nil
+}
+
+func (s *VolumesService) copyVolume(ctx context.Context, srcPath string, dst volume.Volume, dstRef string) error {
+	dstPath, err := dst.Mount(dstRef)
+	if err != nil {
+		if err := s.vs.Release(ctx, dst.Name(), dstRef); err != nil {
+			logrus.WithError(err).WithField("volume", dst.Name()).Warn("failed to release volume reference")
+		}
+		return err
+	}
+	defer s.releaseTransfer(ctx, dst, dstRef)
+
+	return copyVolumeData(srcPath, dstPath)
+}
+
+// mountForTransfer references and mounts the volume for an export or an
+// import, and returns the reference to release once done.
+func (s *VolumesService) mountForTransfer(ctx context.Context, name, op string) (volume.Volume, string, string, error) {
+	ref := op + "-" + stringid.GenerateNonCryptoID()
+	v, err := s.vs.Get(ctx, name, opts.WithGetReference(ref))
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	path, err := v.Mount(ref)
+	if err != nil {
+		if err := s.vs.Release(ctx, v.Name(), ref); err != nil {
+			logrus.WithError(err).WithField("volume", v.Name()).Warn("failed to release volume reference")
+		}
+		return nil, "", "", errors.Wrapf(err, "error mounting volume %s", v.Name())
+	}
+	return v, ref, path, nil
+}
+
+func (s *VolumesService) releaseTransfer(ctx context.Context, v volume.Volume, ref string) {
+	if err := v.Unmount(ref); err != nil {
+		logrus.WithError(err).WithField("volume", v.Name()).Warn("failed to unmount volume")
+	}
+	if err := s.vs.Release(ctx, v.Name(), ref); err != nil {
+		logrus.WithError(err).WithField("volume", v.Name()).Warn("failed to release volume reference")
+	}
+}
+
 // Remove removes a volume
 func (s *VolumesService) Remove(ctx context.Context, name string, rmOpts ...opts.RemoveOption) error {
 	var cfg opts.RemoveConfig
@@ -153,10 +330,11 @@ var acceptedPruneFilters = map[string]bool{
 }
 
 var acceptedListFilters = map[string]bool{
-	"dangling": true,
-	"name":     true,
-	"driver":   true,
-	"label":    true,
+	"dangling":      true,
+	"name":          true,
+	"driver":        true,
+	"label":         true,
+	"referenced-by": true,
 }
 
 // LocalVolumesSize gets all local volumes and fetches their size on disk
@@ -164,20 +342,35 @@ var acceptedListFilters = map[string]bool{
 // volumes with mount options are not really local even if they are using the
 // local driver.
 func (s *VolumesService) LocalVolumesSize(ctx context.Context) ([]*types.Volume, error) {
-	ls, _, err := s.vs.Find(ctx, And(ByDriver(volume.DefaultDriverName), CustomFilter(func(v volume.Volume) bool {
-		dv, ok := v.(volume.DetailedVolume)
-		return ok && len(dv.Options()) == 0
-	})))
+	ls, _, err := s.vs.Find(ctx, And(ByDriver(volume.DefaultDriverName), CustomFilter(hasNoMountOptions)))
 	if err != nil {
 		return nil, err
 	}
 	return s.volumesToAPI(ctx, ls, calcSize(true)), nil
 }
 
+// hasNoMountOptions returns whether the volume was created without mount
+// options. The size option of the local driver only sets a quota, and is not
+// a mount option.
+func hasNoMountOptions(v volume.Volume) bool {
+	dv, ok := v.(volume.DetailedVolume)
+	if !ok {
+		return false
+	}
+	for k := range dv.Options() {
+		if k != "size" {
+			return false
+		}
+	}
+	return true
+}
+
 // Prune removes (local) volumes which match the past in filter arguments.
 // Note that this intentionally skips volumes with mount options as there would
 // be no space reclaimed in this case.
-func (s *VolumesService) Prune(ctx context.Context, filter filters.Args) (*types.VolumesPruneReport, error) {
+// If dryRun is true, nothing is removed, and the report of what would be
+// removed is returned.
+func (s *VolumesService) Prune(ctx context.Context, filter filters.Args, dryRun bool) (*types.VolumesPruneReport, error) {
 	if !atomic.CompareAndSwapInt32(&s.pruneRunning, 0, 1) {
 		return nil, errdefs.Conflict(errors.New("a prune operation is already running"))
 	}
@@ -187,10 +380,7 @@ func (s *VolumesService) Prune(ctx context.Context, filter filters.Args) (*types
 	if err != nil {
 		return nil, err
 	}
-	ls, _, err := s.vs.Find(ctx, And(ByDriver(volume.DefaultDriverName), ByReferenced(false), by, CustomFilter(func(v volume.Volume) bool {
-		dv, ok := v.(volume.DetailedVolume)
-		return ok && len(dv.Options()) == 0
-	})))
+	ls, _, err := s.vs.Find(ctx, And(ByDriver(volume.DefaultDriverName), ByReferenced(false), by, CustomFilter(hasNoMountOptions)))
 	if err != nil {
 		return nil, err
 	}
@@ -211,9 +401,11 @@ func (s *VolumesService) Prune(ctx context.Context, filter filters.Args) (*types
 		if err != nil {
 			logrus.WithField("volume", v.Name()).WithError(err).Warn("could not determine size of volume")
 		}
-		if err := s.vs.Remove(ctx, v); err != nil {
-			logrus.WithError(err).WithField("volume", v.Name()).Warnf("Could not determine size of volume")
-			continue
+		if !dryRun {
+			if err := s.vs.Remove(ctx, v); err != nil {
+				logrus.WithError(err).WithField("volume", v.Name()).Warnf("Could not determine size of volume")
+				continue
+			}
 		}
 		rep.SpaceReclaimed += uint64(vSize)
 		rep.VolumesDeleted = append(rep.VolumesDeleted, v.Name())
@@ -228,13 +420,18 @@ func (s *VolumesService) List(ctx context.Context, filter filters.Args) (volumes
 	if err != nil {
 		return nil, nil, err
 	}
+	if filter.Contains("referenced-by") {
+		by = And(by, CustomFilter(func(v volume.Volume) bool {
+			return isReferencedBy(s.references(v), filter.Get("referenced-by"))
+		}))
+	}
 
 	volumes, warnings, err := s.vs.Find(ctx, by)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.volumesToAPI(ctx, volumes, useCachedPath(true)), warnings, nil
+	return s.volumesToAPI(ctx, volumes, useCachedPath(true), resolveReferences(true)), warnings, nil
 }
 
 // Shutdown shuts down the image service and dependencies
@@ -1,13 +1,18 @@
 package service
 
 import (
+	"archive/tar"
 	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/reexec"
 	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/local"
@@ -15,8 +20,13 @@ import (
 	"github.com/docker/docker/volume/testutils"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
+	"gotest.tools/skip"
 )
 
+func init() {
+	reexec.Init()
+}
+
 func TestLocalVolumeSize(t *testing.T) {
 	t.Parallel()
 
@@ -64,3 +74,166 @@ func TestLocalVolumeSize(t *testing.T) {
 		}
 	}
 }
+
+func TestVolumeExportImport(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "skipping test that requires root")
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := local.New(dir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	assert.Assert(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	ctx := context.Background()
+	src, err := service.Create(ctx, "src", volume.DefaultDriverName)
+	assert.Assert(t, err)
+	dst, err := service.Create(ctx, "dst", volume.DefaultDriverName)
+	assert.Assert(t, err)
+
+	assert.Assert(t, os.MkdirAll(filepath.Join(src.Mountpoint, "dir"), 0755))
+	assert.Assert(t, ioutil.WriteFile(filepath.Join(src.Mountpoint, "dir", "data"), []byte("hello"), 0644))
+
+	rdr, err := service.Export(ctx, "src", archive.Gzip)
+	assert.Assert(t, err)
+
+	// The volume is referenced until the export is done.
+	err = service.Remove(ctx, "src")
+	assert.Check(t, IsInUse(err), err)
+
+	err = service.Import(ctx, "dst", rdr)
+	assert.Check(t, err)
+	assert.Check(t, rdr.Close())
+
+	b, err := ioutil.ReadFile(filepath.Join(dst.Mountpoint, "dir", "data"))
+	assert.Check(t, err)
+	assert.Check(t, is.Equal("hello", string(b)))
+
+	assert.Check(t, service.Remove(ctx, "src"))
+	assert.Check(t, service.Remove(ctx, "dst"))
+
+	_, err = service.Export(ctx, "src", archive.Uncompressed)
+	assert.Check(t, IsNotExist(err), err)
+	err = service.Import(ctx, "src", rdr)
+	assert.Check(t, IsNotExist(err), err)
+}
+
+func TestVolumeImportRemapped(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "skipping test that requires root")
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := local.New(dir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	assert.Assert(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+	service.idMappings = idtools.NewIDMappingsFromMaps(
+		[]idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		[]idtools.IDMap{{ContainerID: 0, HostID: 200000, Size: 65536}},
+	)
+
+	ctx := context.Background()
+	src, err := service.Create(ctx, "src", volume.DefaultDriverName)
+	assert.Assert(t, err)
+	dst, err := service.Create(ctx, "dst", volume.DefaultDriverName)
+	assert.Assert(t, err)
+
+	p := filepath.Join(src.Mountpoint, "data")
+	assert.Assert(t, ioutil.WriteFile(p, []byte("hello"), 0644))
+	assert.Assert(t, os.Chown(p, 100001, 200002))
+
+	rdr, err := service.Export(ctx, "src", archive.Uncompressed)
+	assert.Assert(t, err)
+	defer rdr.Close()
+
+	// The archive has the IDs of the containers.
+	tr := tar.NewReader(rdr)
+	hdr, err := tr.Next()
+	assert.Assert(t, err)
+	assert.Check(t, is.Equal("data", hdr.Name))
+	assert.Check(t, is.Equal(1, hdr.Uid))
+	assert.Check(t, is.Equal(2, hdr.Gid))
+
+	// The IDs are mapped to the ones of the host on import.
+	imported, err := archive.Generate("data", "hello")
+	assert.Assert(t, err)
+	assert.Assert(t, service.Import(ctx, "dst", imported))
+
+	fi, err := os.Stat(filepath.Join(dst.Mountpoint, "data"))
+	assert.Assert(t, err)
+	st := fi.Sys().(*syscall.Stat_t)
+	assert.Check(t, is.Equal(uint32(100000), st.Uid))
+	assert.Check(t, is.Equal(uint32(200000), st.Gid))
+}
+
+func TestVolumeClone(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	dir, err := ioutil.TempDir("", t.Name())
+	assert.Assert(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := local.New(dir, idtools.IDPair{UID: os.Getuid(), GID: os.Getegid()})
+	assert.Assert(t, err)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	ctx := context.Background()
+	src, err := service.Create(ctx, "src", volume.DefaultDriverName, opts.WithCreateLabels(map[string]string{"seed": "true"}))
+	assert.Assert(t, err)
+	assert.Assert(t, os.MkdirAll(filepath.Join(src.Mountpoint, "dir"), 0755))
+	assert.Assert(t, ioutil.WriteFile(filepath.Join(src.Mountpoint, "dir", "data"), []byte("hello"), 0644))
+
+	clone, err := service.Clone(ctx, "src", "clone", nil)
+	assert.Assert(t, err)
+	assert.Check(t, is.Equal("clone", clone.Name))
+	assert.Check(t, is.Equal(volume.DefaultDriverName, clone.Driver))
+	assert.Check(t, is.DeepEqual(map[string]string{"seed": "true"}, clone.Labels))
+
+	b, err := ioutil.ReadFile(filepath.Join(clone.Mountpoint, "dir", "data"))
+	assert.Check(t, err)
+	assert.Check(t, is.Equal("hello", string(b)))
+
+	// The volumes are not referenced once cloned.
+	assert.Check(t, service.Remove(ctx, "src"))
+	assert.Check(t, ioutil.WriteFile(filepath.Join(clone.Mountpoint, "dir", "data"), []byte("world"), 0644))
+
+	clone2, err := service.Clone(ctx, "clone", "", map[string]string{})
+	assert.Assert(t, err)
+	assert.Check(t, clone2.Name != "")
+	assert.Check(t, is.Len(clone2.Labels, 0))
+	b, err = ioutil.ReadFile(filepath.Join(clone2.Mountpoint, "dir", "data"))
+	assert.Check(t, err)
+	assert.Check(t, is.Equal("world", string(b)))
+
+	_, err = service.Clone(ctx, "clone", clone2.Name, nil)
+	assert.Check(t, errdefs.IsConflict(err), err)
+	_, err = service.Clone(ctx, "src", "clone3", nil)
+	assert.Check(t, IsNotExist(err), err)
+
+	// The clone of a volume mounted from a device would mount the same
+	// device.
+	bindDir := filepath.Join(dir, "bind")
+	assert.Assert(t, os.Mkdir(bindDir, 0755))
+	_, err = service.Create(ctx, "bind", volume.DefaultDriverName, opts.WithCreateOptions(map[string]string{"type": "none", "o": "bind", "device": bindDir}))
+	assert.Assert(t, err)
+	_, err = service.Clone(ctx, "bind", "clone4", nil)
+	assert.Check(t, errdefs.IsInvalidParameter(err), err)
+	_, err = service.Get(ctx, "clone4")
+	assert.Check(t, IsNotExist(err), err)
+}
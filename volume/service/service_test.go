@@ -6,8 +6,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/service/opts"
@@ -158,6 +160,73 @@ func TestServiceGet(t *testing.T) {
 	assert.Assert(t, errdefs.IsConflict(err), err)
 }
 
+func TestServiceReferences(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(testutils.NewFakeDriver("d1"), "d1"))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+	service.refResolver = fakeReferenceResolver{
+		"c1": {ContainerID: "c1", ContainerName: "web", Destination: "/data"},
+		"c2": {ContainerID: "c2", ContainerName: "db", Destination: "/var/lib/db"},
+	}
+	ctx := context.Background()
+
+	_, err := service.Create(ctx, "v1", "d1", opts.WithCreateReference("c1"))
+	assert.Assert(t, err)
+	_, err = service.Create(ctx, "v2", "d1", opts.WithCreateReference("c2"))
+	assert.Assert(t, err)
+	_, err = service.Get(ctx, "v2", opts.WithGetReference("export"))
+	assert.Assert(t, err)
+	_, err = service.Create(ctx, "v3", "d1", opts.WithCreateReference("export"))
+	assert.Assert(t, err)
+
+	v, err := service.Get(ctx, "v1")
+	assert.Assert(t, err)
+	assert.Check(t, is.Len(v.References, 0))
+
+	v, err = service.Get(ctx, "v2", opts.WithGetResolveReferences)
+	assert.Assert(t, err)
+	assert.Check(t, is.DeepEqual([]types.VolumeReference{{ContainerID: "c2", ContainerName: "db", Destination: "/var/lib/db"}}, v.References))
+
+	// References which are not containers are skipped.
+	v, err = service.Get(ctx, "v3", opts.WithGetResolveReferences)
+	assert.Assert(t, err)
+	assert.Check(t, is.Len(v.References, 0))
+
+	ls, _, err := service.List(ctx, filters.NewArgs())
+	assert.Assert(t, err)
+	assert.Assert(t, is.Len(ls, 3))
+	for _, v := range ls {
+		switch v.Name {
+		case "v1":
+			assert.Check(t, is.Len(v.References, 1))
+		case "v2":
+			assert.Check(t, is.Len(v.References, 1))
+		default:
+			assert.Check(t, is.Len(v.References, 0))
+		}
+	}
+
+	ls, _, err = service.List(ctx, filters.NewArgs(filters.Arg("referenced-by", "web")))
+	assert.Assert(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Check(t, is.Equal("v1", ls[0].Name))
+
+	ls, _, err = service.List(ctx, filters.NewArgs(filters.Arg("referenced-by", "/web"), filters.Arg("referenced-by", "c2")))
+	assert.Assert(t, err)
+	assert.Check(t, is.Len(ls, 2))
+
+	ls, _, err = service.List(ctx, filters.NewArgs(filters.Arg("referenced-by", "export")))
+	assert.Assert(t, err)
+	assert.Check(t, is.Len(ls, 0))
+
+	_, err = service.Prune(ctx, filters.NewArgs(filters.Arg("referenced-by", "web")), true)
+	assert.Check(t, errdefs.IsInvalidParameter(err), err)
+}
+
 func TestServicePrune(t *testing.T) {
 	t.Parallel()
 
@@ -174,11 +243,19 @@ func TestServicePrune(t *testing.T) {
 	_, err = service.Create(ctx, "test2", "other")
 	assert.Assert(t, err)
 
-	pr, err := service.Prune(ctx, filters.NewArgs(filters.Arg("label", "banana")))
+	pr, err := service.Prune(ctx, filters.NewArgs(filters.Arg("label", "banana")), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 0))
 
-	pr, err = service.Prune(ctx, filters.NewArgs())
+	pr, err = service.Prune(ctx, filters.NewArgs(), true)
+	assert.Assert(t, err)
+	assert.Assert(t, is.Len(pr.VolumesDeleted, 1))
+	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test"))
+
+	_, err = service.Get(ctx, "test")
+	assert.Assert(t, err)
+
+	pr, err = service.Prune(ctx, filters.NewArgs(), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 1))
 	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test"))
@@ -193,7 +270,7 @@ func TestServicePrune(t *testing.T) {
 	_, err = service.Create(ctx, "test", volume.DefaultDriverName)
 	assert.Assert(t, err)
 
-	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label!", "banana")))
+	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label!", "banana")), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 1))
 	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test"))
@@ -203,18 +280,18 @@ func TestServicePrune(t *testing.T) {
 
 	_, err = service.Create(ctx, "test", volume.DefaultDriverName, opts.WithCreateLabels(map[string]string{"banana": ""}))
 	assert.Assert(t, err)
-	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label!", "banana")))
+	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label!", "banana")), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 0))
 
 	_, err = service.Create(ctx, "test3", volume.DefaultDriverName, opts.WithCreateLabels(map[string]string{"banana": "split"}))
 	assert.Assert(t, err)
-	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label!", "banana=split")))
+	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label!", "banana=split")), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 1))
 	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test"))
 
-	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label", "banana=split")))
+	pr, err = service.Prune(ctx, filters.NewArgs(filters.Arg("label", "banana=split")), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 1))
 	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test3"))
@@ -222,12 +299,12 @@ func TestServicePrune(t *testing.T) {
 	v, err = service.Create(ctx, "test", volume.DefaultDriverName, opts.WithCreateReference(t.Name()))
 	assert.Assert(t, err)
 
-	pr, err = service.Prune(ctx, filters.NewArgs())
+	pr, err = service.Prune(ctx, filters.NewArgs(), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 0))
 	assert.Assert(t, service.Release(ctx, v.Name, t.Name()))
 
-	pr, err = service.Prune(ctx, filters.NewArgs())
+	pr, err = service.Prune(ctx, filters.NewArgs(), false)
 	assert.Assert(t, err)
 	assert.Assert(t, is.Len(pr.VolumesDeleted, 1))
 	assert.Assert(t, is.Equal(pr.VolumesDeleted[0], "test"))
@@ -241,7 +318,7 @@ func newTestService(t *testing.T, ds *volumedrivers.Store) (*VolumesService, fun
 
 	store, err := NewStore(dir, ds)
 	assert.Assert(t, err)
-	s := &VolumesService{vs: store, eventLogger: dummyEventLogger{}}
+	s := &VolumesService{vs: store, eventLogger: dummyEventLogger{}, idMappings: &idtools.IDMappings{}}
 	return s, func() {
 		assert.Check(t, s.Shutdown())
 		assert.Check(t, os.RemoveAll(dir))
@@ -251,3 +328,17 @@ func newTestService(t *testing.T, ds *volumedrivers.Store) (*VolumesService, fun
 type dummyEventLogger struct{}
 
 func (dummyEventLogger) LogVolumeEvent(_, _ string, _ map[string]string) {}
+
+// fakeReferenceResolver resolves the references which are the keys of the
+// map, as containers.
+type fakeReferenceResolver map[string]types.VolumeReference
+
+func (r fakeReferenceResolver) VolumeReferences(_ string, refs []string) []types.VolumeReference {
+	var out []types.VolumeReference
+	for _, ref := range refs {
+		if c, ok := r[ref]; ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -465,6 +465,21 @@ func (s *VolumeStore) Create(ctx context.Context, name, driverName string, creat
 	default:
 	}
 
+	if cfg.Exclusive {
+		// Only the volumes known by the store and the target driver are
+		// checked, probing all the drivers under the name lock is slow.
+		if _, exists := s.getNamed(name); exists {
+			return nil, &OpErr{Err: errVolumeExists, Name: name, Op: "create"}
+		}
+		v, err := lookupVolume(ctx, s.drivers, driverName, name)
+		if err != nil {
+			return nil, &OpErr{Err: err, Name: name, Op: "create"}
+		}
+		if v != nil {
+			return nil, &OpErr{Err: errVolumeExists, Name: name, Op: "create"}
+		}
+	}
+
 	v, err := s.create(ctx, name, driverName, cfg.Options, cfg.Labels)
 	if err != nil {
 		if _, ok := err.(*OpErr); ok {
@@ -843,6 +858,16 @@ func (s *VolumeStore) CountReferences(v volume.Volume) int {
 	return len(s.refs[name])
 }
 
+// References returns the references to a given volume.
+func (s *VolumeStore) References(v volume.Volume) []string {
+	name := normalizeVolumeName(v.Name())
+
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	return s.getRefs(name)
+}
+
 func unwrapVolume(v volume.Volume) volume.Volume {
 	if vol, ok := v.(volumeWrapper); ok {
 		return vol.Volume
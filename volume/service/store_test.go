@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/volume"
 	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/docker/docker/volume/service/opts"
@@ -49,6 +50,32 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateExclusive(t *testing.T) {
+	t.Parallel()
+
+	s, cleanup := setupTest(t)
+	defer cleanup()
+	driver := volumetestutils.NewFakeDriver("fake")
+	s.drivers.Register(driver, "fake")
+
+	ctx := context.Background()
+	_, err := s.Create(ctx, "fake1", "fake", opts.WithCreateExclusive())
+	assert.NilError(t, err)
+
+	_, err = s.Create(ctx, "fake1", "fake", opts.WithCreateExclusive())
+	assert.Check(t, is.ErrorContains(err, "volume already exists"))
+	assert.Check(t, errdefs.IsConflict(err), err)
+
+	_, err = s.Create(ctx, "fake1", "fake")
+	assert.Check(t, err)
+
+	// A volume of the driver which is not known by the store yet
+	_, err = driver.Create("fake2", nil)
+	assert.NilError(t, err)
+	_, err = s.Create(ctx, "fake2", "fake", opts.WithCreateExclusive())
+	assert.Check(t, is.ErrorContains(err, "volume already exists"))
+}
+
 func TestRemove(t *testing.T) {
 	t.Parallel()
 